@@ -38,6 +38,30 @@ var _ = Describe("GET CONSUMER GROUP BY ID:", func() {
 			itShouldReturnSpecificConsumer(groupId, name, &group.ConsumerGroup)
 		})
 
+		Context("and tries to get consumer group joined by several clients", func() {
+			client := createAuthorizedConnection()
+			streamId, _ := successfullyCreateStream(prefix, client)
+			defer deleteStreamAfterTests(streamId, client)
+			topicId, _ := successfullyCreateTopic(streamId, client)
+			groupId, name := successfullyCreateConsumer(streamId, topicId, client)
+			var clientIds []int
+			for range 2 {
+				member := createAuthorizedConnection()
+				defer member.Close()
+				successfullyJoinConsumer(streamId, topicId, groupId, member)
+				me, err := member.GetMe()
+				itShouldNotReturnError(err)
+				if me != nil {
+					clientIds = append(clientIds, int(me.ID))
+				}
+			}
+			group, err := client.GetConsumerGroup(iggcon.NewIdentifier(streamId), iggcon.NewIdentifier(topicId), iggcon.NewIdentifier(groupId))
+
+			itShouldNotReturnError(err)
+			itShouldReturnSpecificConsumer(groupId, name, &group.ConsumerGroup)
+			itShouldAssignPartitionsToMembers(group, clientIds, 2)
+		})
+
 		Context("and tries to get consumer from non-existing stream", func() {
 			client := createAuthorizedConnection()
 
@@ -139,3 +139,29 @@ func itShouldSuccessfullyLeaveConsumer(streamId int, topicId int, groupId int, c
 
 	itShouldNotReturnError(err)
 }
+
+func itShouldAssignPartitionsToMembers(group *iggcon.ConsumerGroupDetails, clientIds []int, partitionsCount int) {
+	It("should contain every member", func() {
+		Expect(group).NotTo(BeNil())
+		Expect(group.MembersCount).To(Equal(len(clientIds)))
+		memberIds := make([]int, 0, len(group.Members))
+		for _, member := range group.Members {
+			memberIds = append(memberIds, member.ID)
+		}
+		Expect(memberIds).To(ConsistOf(clientIds))
+	})
+
+	It("should assign every partition to exactly one member", func() {
+		Expect(group).NotTo(BeNil())
+		var partitions []int
+		for _, member := range group.Members {
+			Expect(member.Partitions).To(HaveLen(member.PartitionsCount))
+			partitions = append(partitions, member.Partitions...)
+		}
+		expected := make([]int, 0, partitionsCount)
+		for id := 1; id <= partitionsCount; id++ {
+			expected = append(expected, id)
+		}
+		Expect(partitions).To(ConsistOf(expected))
+	})
+}
@@ -19,6 +19,7 @@ package tcp_test
 
 import (
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	"github.com/apache/iggy/foreign/go/tcp"
 	. "github.com/onsi/ginkgo/v2"
 )
 
@@ -41,6 +42,22 @@ var _ = Describe("SEND MESSAGES:", func() {
 			itShouldSuccessfullyPublishMessages(streamId, topicId, messages, client)
 		})
 
+		Context("and tries to send messages with checksums verified on poll", func() {
+			client := createAuthorizedConnection(tcp.WithMessageChecksums(), tcp.WithChecksumVerification())
+			streamId, _ := successfullyCreateStream("1"+prefix, client)
+			defer deleteStreamAfterTests(streamId, client)
+			topicId, _ := successfullyCreateTopic(streamId, client)
+			messages := createDefaultMessages()
+			err := client.SendMessages(
+				iggcon.NewIdentifier(streamId),
+				iggcon.NewIdentifier(topicId),
+				iggcon.None(),
+				messages,
+			)
+			itShouldNotReturnError(err)
+			itShouldSuccessfullyPublishMessages(streamId, topicId, messages, client)
+		})
+
 		Context("and tries to send messages to the non existing topic", func() {
 			client := createAuthorizedConnection()
 			streamId, _ := successfullyCreateStream("2"+prefix, client)
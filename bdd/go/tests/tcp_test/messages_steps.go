@@ -42,6 +42,19 @@ func createDefaultMessages() []iggcon.IggyMessage {
 	return []iggcon.IggyMessage{msg1, msg2}
 }
 
+func successfullySendMessages(streamId int, topicId int, client iggycli.Client) []iggcon.IggyMessage {
+	messages := createDefaultMessages()
+	err := client.SendMessages(
+		iggcon.NewIdentifier(streamId),
+		iggcon.NewIdentifier(topicId),
+		iggcon.None(),
+		messages,
+	)
+
+	itShouldNotReturnError(err)
+	return messages
+}
+
 func itShouldSuccessfullyPublishMessages(streamId int, topicId int, messages []iggcon.IggyMessage, client iggycli.Client) {
 	result, err := client.PollMessages(
 		iggcon.NewIdentifier(streamId),
@@ -35,6 +35,18 @@ var _ = Describe("GET STREAM BY ID:", func() {
 			itShouldReturnSpecificStream(streamId, name, *stream)
 		})
 
+		Context("and tries to get existing stream with topics", func() {
+			client := createAuthorizedConnection()
+			streamId, name := successfullyCreateStream(prefix, client)
+			defer deleteStreamAfterTests(streamId, client)
+			topicId, topicName := successfullyCreateTopic(streamId, client)
+			stream, err := client.GetStream(iggcon.NewIdentifier(streamId))
+
+			itShouldNotReturnError(err)
+			itShouldReturnSpecificStream(streamId, name, *stream)
+			itShouldContainSpecificTopic(topicId, topicName, stream.Topics)
+		})
+
 		Context("and tries to get non-existing stream", func() {
 			client := createAuthorizedConnection()
 			streamId := int(createRandomUInt32())
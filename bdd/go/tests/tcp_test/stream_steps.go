@@ -114,6 +114,23 @@ func itShouldSuccessfullyDeleteStream(id int, client iggycli.Client) {
 	})
 }
 
+func itShouldSuccessfullyPurgeStream(streamId int, topicId int, client iggycli.Client) {
+	stream, err := client.GetStream(iggcon.NewIdentifier(streamId))
+
+	It("should keep stream with id "+strconv.Itoa(streamId), func() {
+		Expect(stream).NotTo(BeNil())
+		Expect(stream.Id).To(Equal(streamId))
+		Expect(stream.TopicsCount).To(Equal(1))
+	})
+
+	It("should not contain any messages", func() {
+		Expect(stream).NotTo(BeNil())
+		Expect(stream.MessagesCount).To(Equal(uint64(0)))
+	})
+	itShouldNotReturnError(err)
+	itShouldSuccessfullyPurgeTopic(streamId, topicId, client)
+}
+
 func deleteStreamAfterTests(streamId int, client iggycli.Client) {
 	_ = client.DeleteStream(iggcon.NewIdentifier(streamId))
 }
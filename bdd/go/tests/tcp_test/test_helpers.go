@@ -27,8 +27,8 @@ import (
 	"github.com/apache/iggy/foreign/go/tcp"
 )
 
-func createAuthorizedConnection() iggycli.Client {
-	cli := createClient()
+func createAuthorizedConnection(options ...tcp.Option) iggycli.Client {
+	cli := createClient(options...)
 	_, err := cli.LoginUser("iggy", "iggy")
 	if err != nil {
 		panic(err)
@@ -36,14 +36,14 @@ func createAuthorizedConnection() iggycli.Client {
 	return cli
 }
 
-func createClient() iggycli.Client {
+func createClient(options ...tcp.Option) iggycli.Client {
 	addr := os.Getenv("IGGY_TCP_ADDRESS")
 	if addr == "" {
 		addr = "127.0.0.1:8090"
 	}
 	cli, err := iggycli.NewIggyClient(
 		iggycli.WithTcp(
-			tcp.WithServerAddress(addr),
+			append([]tcp.Option{tcp.WithServerAddress(addr)}, options...)...,
 		),
 	)
 	if err != nil {
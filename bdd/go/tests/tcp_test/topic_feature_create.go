@@ -18,9 +18,10 @@
 package tcp_test
 
 import (
-	"math"
+	"time"
 
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
 	. "github.com/onsi/ginkgo/v2"
 )
 
@@ -31,37 +32,33 @@ var _ = Describe("CREATE TOPIC:", func() {
 			client := createAuthorizedConnection()
 			streamId, _ := successfullyCreateStream(prefix, client)
 			topicId := 1
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
+			topicConfig.MessageExpiry = iggcon.ExpireAfter(time.Second)
 			name := createRandomString(32)
 			defer deleteStreamAfterTests(streamId, client)
 			_, err := client.CreateTopic(
 				iggcon.NewIdentifier(streamId),
 				name,
 				2,
-				1,
-				1000,
-				math.MaxUint64,
-				&replicationFactor,
+				topicConfig,
 				&topicId)
 
 			itShouldNotReturnError(err)
 			itShouldSuccessfullyCreateTopic(streamId, topicId, name, client)
+			itShouldHaveMessageExpiry(streamId, topicId, topicConfig.MessageExpiry, client)
 		})
 
 		Context("and tries to create topic for a non existing stream", func() {
 			client := createAuthorizedConnection()
 			streamId := int(createRandomUInt32())
 			topicId := 1
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			name := createRandomString(32)
 			_, err := client.CreateTopic(
 				iggcon.NewIdentifier(streamId),
 				name,
 				2,
-				1,
-				1000,
-				math.MaxUint64,
-				&replicationFactor,
+				topicConfig,
 				&topicId)
 
 			itShouldReturnSpecificError(err, "stream_id_not_found")
@@ -73,16 +70,13 @@ var _ = Describe("CREATE TOPIC:", func() {
 			defer deleteStreamAfterTests(streamId, client)
 			_, name := successfullyCreateTopic(streamId, client)
 
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			topicId := int(createRandomUInt32())
 			_, err := client.CreateTopic(
 				iggcon.NewIdentifier(streamId),
 				name,
 				2,
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor,
+				topicConfig,
 				&topicId)
 			itShouldReturnSpecificError(err, "topic_name_already_exists")
 		})
@@ -93,15 +87,12 @@ var _ = Describe("CREATE TOPIC:", func() {
 			defer deleteStreamAfterTests(streamId, client)
 			topicId, _ := successfullyCreateTopic(streamId, client)
 
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			_, err := client.CreateTopic(
 				iggcon.NewIdentifier(streamId),
 				createRandomString(32),
 				2,
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor,
+				topicConfig,
 				&topicId)
 			itShouldReturnSpecificError(err, "topic_id_already_exists")
 		})
@@ -111,35 +102,45 @@ var _ = Describe("CREATE TOPIC:", func() {
 			streamId, _ := successfullyCreateStream(prefix, client)
 			defer deleteStreamAfterTests(streamId, createAuthorizedConnection())
 
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			topicId := int(createRandomUInt32())
 			_, err := client.CreateTopic(
 				iggcon.NewIdentifier(streamId),
 				createRandomString(256),
 				2,
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor,
+				topicConfig,
 				&topicId)
 
 			itShouldReturnSpecificError(err, "topic_name_too_long")
 		})
+
+		Context("and tries to create topic with more partitions than allowed", func() {
+			client := createAuthorizedConnection()
+			streamId, _ := successfullyCreateStream(prefix, client)
+			defer deleteStreamAfterTests(streamId, client)
+
+			topicId := int(createRandomUInt32())
+			_, err := client.CreateTopic(
+				iggcon.NewIdentifier(streamId),
+				createRandomString(32),
+				iggcon.MaxPartitionsCount+1,
+				iggcon.TopicConfig{},
+				&topicId)
+
+			itShouldReturnSpecificIggyError(err, ierror.TooManyPartitions)
+		})
 	})
 
 	When("User is not logged in", func() {
 		Context("and tries to create topic", func() {
 			client := createClient()
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			topicId := 1
 			_, err := client.CreateTopic(
 				iggcon.NewIdentifier(10),
 				"name",
 				2,
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor,
+				topicConfig,
 				&topicId)
 
 			itShouldReturnUnauthenticatedError(err)
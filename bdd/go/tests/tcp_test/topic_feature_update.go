@@ -18,8 +18,6 @@
 package tcp_test
 
 import (
-	"math"
-
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
 	. "github.com/onsi/ginkgo/v2"
 )
@@ -33,14 +31,11 @@ var _ = Describe("UPDATE TOPIC:", func() {
 			defer deleteStreamAfterTests(streamId, client)
 			topicId, _ := successfullyCreateTopic(streamId, client)
 			newName := createRandomString(128)
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			err := client.UpdateTopic(iggcon.NewIdentifier(streamId),
 				iggcon.NewIdentifier(topicId),
 				newName,
-				1,
-				1,
-				math.MaxUint64,
-				&replicationFactor)
+				topicConfig)
 			itShouldNotReturnError(err)
 			itShouldSuccessfullyUpdateTopic(streamId, topicId, newName, client)
 		})
@@ -51,14 +46,11 @@ var _ = Describe("UPDATE TOPIC:", func() {
 			defer deleteStreamAfterTests(streamId, client)
 			_, topic1Name := successfullyCreateTopic(streamId, client)
 			topic2Id, _ := successfullyCreateTopic(streamId, client)
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			err := client.UpdateTopic(iggcon.NewIdentifier(streamId),
 				iggcon.NewIdentifier(topic2Id),
 				topic1Name,
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor)
+				topicConfig)
 
 			itShouldReturnSpecificError(err, "topic_name_already_exists")
 		})
@@ -67,15 +59,12 @@ var _ = Describe("UPDATE TOPIC:", func() {
 			client := createAuthorizedConnection()
 			streamId := int(createRandomUInt32())
 			topicId := int(createRandomUInt32())
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			err := client.UpdateTopic(
 				iggcon.NewIdentifier(streamId),
 				iggcon.NewIdentifier(topicId),
 				createRandomString(128),
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor)
+				topicConfig)
 
 			itShouldReturnSpecificError(err, "stream_id_not_found")
 		})
@@ -85,15 +74,12 @@ var _ = Describe("UPDATE TOPIC:", func() {
 			streamId, _ := successfullyCreateStream(prefix, client)
 			defer deleteStreamAfterTests(streamId, createAuthorizedConnection())
 			topicId := int(createRandomUInt32())
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			err := client.UpdateTopic(
 				iggcon.NewIdentifier(streamId),
 				iggcon.NewIdentifier(topicId),
 				createRandomString(128),
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor)
+				topicConfig)
 
 			itShouldReturnSpecificError(err, "topic_id_not_found")
 		})
@@ -103,15 +89,12 @@ var _ = Describe("UPDATE TOPIC:", func() {
 			streamId, _ := successfullyCreateStream(prefix, client)
 			defer deleteStreamAfterTests(streamId, createAuthorizedConnection())
 			topicId, _ := successfullyCreateTopic(streamId, client)
-			replicationFactor := uint8(1)
+			topicConfig := defaultTopicConfig()
 			err := client.UpdateTopic(
 				iggcon.NewIdentifier(streamId),
 				iggcon.NewIdentifier(topicId),
 				createRandomString(256),
-				1,
-				0,
-				math.MaxUint64,
-				&replicationFactor)
+				topicConfig)
 
 			itShouldReturnSpecificError(err, "topic_name_too_long")
 		})
@@ -18,7 +18,6 @@
 package tcp_test
 
 import (
-	"math"
 	"strconv"
 
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
@@ -30,18 +29,24 @@ import (
 
 //operations
 
+// defaultTopicConfig keeps the server defaults, except for the topic size which is unlimited.
+func defaultTopicConfig() iggcon.TopicConfig {
+	return iggcon.TopicConfig{
+		CompressionAlgorithm: iggcon.CompressionNone,
+		MessageExpiry:        iggcon.ExpiryServerDefault,
+		MaxTopicSize:         iggcon.MaxTopicSizeUnlimited,
+		ReplicationFactor:    1,
+	}
+}
+
 func successfullyCreateTopic(streamId int, client iggycli.Client) (int, string) {
 	topicId := int(createRandomUInt32())
-	replicationFactor := uint8(1)
 	name := createRandomString(128)
 	_, err := client.CreateTopic(
 		iggcon.NewIdentifier(streamId),
 		name,
 		2,
-		1,
-		0,
-		math.MaxUint64,
-		&replicationFactor,
+		defaultTopicConfig(),
 		&topicId)
 
 	itShouldSuccessfullyCreateTopic(streamId, topicId, name, client)
@@ -102,6 +107,16 @@ func itShouldSuccessfullyCreateTopic(streamId int, topicId int, expectedName str
 	itShouldNotReturnError(err)
 }
 
+func itShouldHaveMessageExpiry(streamId int, topicId int, expected iggcon.IggyExpiry, client iggycli.Client) {
+	topic, err := client.GetTopic(iggcon.NewIdentifier(streamId), iggcon.NewIdentifier(topicId))
+
+	It("should keep message expiry of "+strconv.FormatUint(uint64(expected), 10)+"µs", func() {
+		Expect(topic).NotTo(BeNil())
+		Expect(topic.MessageExpiry).To(Equal(expected))
+	})
+	itShouldNotReturnError(err)
+}
+
 func itShouldSuccessfullyUpdateTopic(streamId int, topicId int, expectedName string, client iggycli.Client) {
 	topic, err := client.GetTopic(iggcon.NewIdentifier(streamId), iggcon.NewIdentifier(topicId))
 
@@ -125,3 +140,19 @@ func itShouldSuccessfullyDeleteTopic(streamId int, topicId int, client iggycli.C
 		Expect(topic).To(BeNil())
 	})
 }
+
+func itShouldSuccessfullyPurgeTopic(streamId int, topicId int, client iggycli.Client) {
+	topic, err := client.GetTopic(iggcon.NewIdentifier(streamId), iggcon.NewIdentifier(topicId))
+
+	It("should keep topic with id "+strconv.Itoa(topicId), func() {
+		Expect(topic).NotTo(BeNil())
+		Expect(topic.Id).To(Equal(topicId))
+		Expect(topic.PartitionsCount).To(Equal(2))
+	})
+
+	It("should not contain any messages", func() {
+		Expect(topic).NotTo(BeNil())
+		Expect(topic.MessagesCount).To(Equal(uint64(0)))
+	})
+	itShouldNotReturnError(err)
+}
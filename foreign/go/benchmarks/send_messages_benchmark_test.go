@@ -116,10 +116,7 @@ func ensureInfrastructureIsInitialized(cli iggycli.Client, streamId int) error {
 			iggcon.NewIdentifier(streamId),
 			"benchmark",
 			1,
-			1,
-			0,
-			1,
-			nil,
+			iggcon.TopicConfig{CompressionAlgorithm: iggcon.CompressionNone},
 			nil,
 		)
 
@@ -62,6 +62,18 @@ func GetOffset(request GetConsumerOffsetRequest) []byte {
 	return bytes
 }
 
+func DeleteOffset(request DeleteConsumerOffsetRequest) []byte {
+	if request.PartitionId == nil {
+		request.PartitionId = new(uint32)
+	}
+	bytes := make([]byte, 6+request.StreamId.Length+request.TopicId.Length+request.Consumer.Id.Length+5)
+	bytes[0] = byte(request.Consumer.Kind)
+	position := 7 + request.StreamId.Length + request.TopicId.Length + request.Consumer.Id.Length
+	copy(bytes[1:position], SerializeIdentifiers(request.Consumer.Id, request.StreamId, request.TopicId))
+	binary.LittleEndian.PutUint32(bytes[position:position+4], *request.PartitionId)
+	return bytes
+}
+
 func CreatePartitions(request CreatePartitionsRequest) []byte {
 	bytes := make([]byte, 8+request.StreamId.Length+request.TopicId.Length)
 	position := 4 + request.StreamId.Length + request.TopicId.Length
@@ -80,6 +92,28 @@ func DeletePartitions(request DeletePartitionsRequest) []byte {
 	return bytes
 }
 
+func FlushUnsavedBuffer(request FlushUnsavedBufferRequest) []byte {
+	bytes := make([]byte, 9+request.StreamId.Length+request.TopicId.Length)
+	position := 4 + request.StreamId.Length + request.TopicId.Length
+	copy(bytes[0:position], SerializeIdentifiers(request.StreamId, request.TopicId))
+	binary.LittleEndian.PutUint32(bytes[position:position+4], request.PartitionId)
+	if request.Fsync {
+		bytes[position+4] = 1
+	}
+
+	return bytes
+}
+
+func DeleteSegments(request DeleteSegmentsRequest) []byte {
+	bytes := make([]byte, 12+request.StreamId.Length+request.TopicId.Length)
+	position := 4 + request.StreamId.Length + request.TopicId.Length
+	copy(bytes[0:position], SerializeIdentifiers(request.StreamId, request.TopicId))
+	binary.LittleEndian.PutUint32(bytes[position:position+4], request.PartitionId)
+	binary.LittleEndian.PutUint32(bytes[position+4:position+8], request.SegmentsCount)
+
+	return bytes
+}
+
 //USERS
 
 func SerializeCreateUserRequest(request CreateUserRequest) []byte {
@@ -352,3 +386,14 @@ func SerializeCreatePersonalAccessToken(request CreatePersonalAccessTokenRequest
 	binary.LittleEndian.PutUint32(bytes[len(bytes)-4:], request.Expiry)
 	return bytes
 }
+
+func GetSnapshot(request GetSnapshotRequest) []byte {
+	bytes := make([]byte, 2+len(request.SnapshotTypes))
+	bytes[0] = byte(request.Compression)
+	bytes[1] = byte(len(request.SnapshotTypes))
+	for i, snapshotType := range request.SnapshotTypes {
+		bytes[2+i] = byte(snapshotType)
+	}
+
+	return bytes
+}
@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package binaryserialization
+
+import (
+	"testing"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func TestDeleteOffset(t *testing.T) {
+	partitionId := uint32(5)
+	request := iggcon.DeleteConsumerOffsetRequest{
+		StreamId:    iggcon.NewIdentifier("stream"),
+		TopicId:     iggcon.NewIdentifier(3),
+		Consumer:    iggcon.Consumer{Kind: iggcon.ConsumerKindGroup, Id: iggcon.NewIdentifier(7)},
+		PartitionId: &partitionId,
+	}
+
+	expected := []byte{
+		0x02,                   // Consumer Kind (ConsumerGroup)
+		0x01,                   // ConsumerId Kind (NumericId)
+		0x04,                   // ConsumerId Length (4)
+		0x07, 0x00, 0x00, 0x00, // ConsumerId
+
+		0x02,                               // StreamId Kind (StringId)
+		0x06,                               // StreamId Length (6)
+		0x73, 0x74, 0x72, 0x65, 0x61, 0x6D, // StreamId
+
+		0x01,                   // TopicId Kind (NumericId)
+		0x04,                   // TopicId Length (4)
+		0x03, 0x00, 0x00, 0x00, // TopicId
+
+		0x05, 0x00, 0x00, 0x00, // PartitionId (5)
+	}
+	if serialized := DeleteOffset(request); !areBytesEqual(serialized, expected) {
+		t.Errorf("Serialized bytes are incorrect. \nExpected:\t%v\nGot:\t\t%v", expected, serialized)
+	}
+
+	// The partition is ignored for the consumer groups and sent as 0 when missing.
+	request.PartitionId = nil
+	if serialized := DeleteOffset(request); !areBytesEqual(serialized[len(serialized)-4:], []byte{0, 0, 0, 0}) {
+		t.Errorf("expected a missing partition to be serialized as 0, got %v", serialized)
+	}
+}
+
+func TestDeleteSegments(t *testing.T) {
+	request := iggcon.DeleteSegmentsRequest{
+		StreamId:      iggcon.NewIdentifier(1),
+		TopicId:       iggcon.NewIdentifier(2),
+		PartitionId:   3,
+		SegmentsCount: 4,
+	}
+
+	expected := []byte{
+		0x01,                   // StreamId Kind (NumericId)
+		0x04,                   // StreamId Length (4)
+		0x01, 0x00, 0x00, 0x00, // StreamId
+
+		0x01,                   // TopicId Kind (NumericId)
+		0x04,                   // TopicId Length (4)
+		0x02, 0x00, 0x00, 0x00, // TopicId
+
+		0x03, 0x00, 0x00, 0x00, // PartitionId (3)
+		0x04, 0x00, 0x00, 0x00, // SegmentsCount (4)
+	}
+	if serialized := DeleteSegments(request); !areBytesEqual(serialized, expected) {
+		t.Errorf("Serialized bytes are incorrect. \nExpected:\t%v\nGot:\t\t%v", expected, serialized)
+	}
+}
+
+func TestFlushUnsavedBuffer(t *testing.T) {
+	request := iggcon.FlushUnsavedBufferRequest{
+		StreamId:    iggcon.NewIdentifier(1),
+		TopicId:     iggcon.NewIdentifier(2),
+		PartitionId: 3,
+		Fsync:       true,
+	}
+
+	expected := []byte{
+		0x01,                   // StreamId Kind (NumericId)
+		0x04,                   // StreamId Length (4)
+		0x01, 0x00, 0x00, 0x00, // StreamId
+
+		0x01,                   // TopicId Kind (NumericId)
+		0x04,                   // TopicId Length (4)
+		0x02, 0x00, 0x00, 0x00, // TopicId
+
+		0x03, 0x00, 0x00, 0x00, // PartitionId (3)
+		0x01, // Fsync
+	}
+	if serialized := FlushUnsavedBuffer(request); !areBytesEqual(serialized, expected) {
+		t.Errorf("Serialized bytes are incorrect. \nExpected:\t%v\nGot:\t\t%v", expected, serialized)
+	}
+}
+
+func TestGetSnapshot(t *testing.T) {
+	request := iggcon.GetSnapshotRequest{
+		Compression:   iggcon.SnapshotZstd,
+		SnapshotTypes: []iggcon.SnapshotType{iggcon.SnapshotServerLogs, iggcon.SnapshotServerConfig},
+	}
+
+	expected := []byte{
+		0x04,       // Compression (Zstd)
+		0x02,       // SnapshotTypes Count (2)
+		0x05, 0x06, // SnapshotTypes (ServerLogs, ServerConfig)
+	}
+	if serialized := GetSnapshot(request); !areBytesEqual(serialized, expected) {
+		t.Errorf("Serialized bytes are incorrect. \nExpected:\t%v\nGot:\t\t%v", expected, serialized)
+	}
+}
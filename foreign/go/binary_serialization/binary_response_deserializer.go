@@ -47,42 +47,70 @@ func DeserializeOffset(payload []byte) *ConsumerOffsetInfo {
 	}
 }
 
-func DeserializeStream(payload []byte) *StreamDetails {
-	stream, _ := DeserializeToStream(payload, 0)
-	// TODO implement deserialize topics
+const (
+	streamHeaderSize = 4 + 8 + 4 + 8 + 8 + 1
+	topicHeaderSize  = 4 + 8 + 4 + 8 + 1 + 8 + 1 + 8 + 8 + 1
+	partitionSize    = 4 + 8 + 4 + 8 + 8 + 8
+)
+
+// DeserializeStream reads the stream followed by its topics, which are listed without their partitions.
+func DeserializeStream(payload []byte) (*StreamDetails, error) {
+	stream, position, err := DeserializeToStream(payload, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// The capacity is bounded by the payload length, so that a corrupted count cannot cause a huge allocation.
+	topics := make([]Topic, 0, min(stream.TopicsCount, (len(payload)-position)/topicHeaderSize))
+	for position < len(payload) {
+		topic, readBytes, err := DeserializeToTopic(payload, position)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+		position += readBytes
+	}
+
 	return &StreamDetails{
 		Stream: stream,
-		Topics: nil,
-	}
+		Topics: topics,
+	}, nil
 }
 
-func DeserializeStreams(payload []byte) []Stream {
-	streams := make([]Stream, 0)
+func DeserializeStreams(payload []byte) ([]Stream, error) {
+	streams := make([]Stream, 0, len(payload)/streamHeaderSize)
 	position := 0
 
-	//TODO there's a deserialization bug, investigate this
-	//it occurs only with payload greater than 2 pow 16
 	for position < len(payload) {
-		stream, readBytes := DeserializeToStream(payload, position)
+		stream, readBytes, err := DeserializeToStream(payload, position)
+		if err != nil {
+			return nil, err
+		}
 		streams = append(streams, stream)
 		position += readBytes
 	}
 
-	return streams
+	return streams, nil
 }
 
-func DeserializeToStream(payload []byte, position int) (Stream, int) {
+func DeserializeToStream(payload []byte, position int) (Stream, int, error) {
+	if len(payload)-position < streamHeaderSize {
+		return Stream{}, 0, ierror.InvalidBytesResponse
+	}
 	id := int(binary.LittleEndian.Uint32(payload[position : position+4]))
 	createdAt := binary.LittleEndian.Uint64(payload[position+4 : position+12])
 	topicsCount := int(binary.LittleEndian.Uint32(payload[position+12 : position+16]))
 	sizeBytes := binary.LittleEndian.Uint64(payload[position+16 : position+24])
 	messagesCount := binary.LittleEndian.Uint64(payload[position+24 : position+32])
 	nameLength := int(payload[position+32])
+	if len(payload)-position < streamHeaderSize+nameLength {
+		return Stream{}, 0, ierror.InvalidBytesResponse
+	}
 
 	nameBytes := payload[position+33 : position+33+nameLength]
 	name := string(nameBytes)
 
-	readBytes := 4 + 8 + 4 + 8 + 8 + 1 + nameLength
+	readBytes := streamHeaderSize + nameLength
 
 	return Stream{
 		Id:            id,
@@ -91,7 +119,7 @@ func DeserializeToStream(payload []byte, position int) (Stream, int) {
 		SizeBytes:     sizeBytes,
 		MessagesCount: messagesCount,
 		CreatedAt:     createdAt,
-	}, readBytes
+	}, readBytes, nil
 }
 
 func DeserializeFetchMessagesResponse(payload []byte, compression IggyMessageCompression) (*PolledMessage, error) {
@@ -159,6 +187,18 @@ func DeserializeFetchMessagesResponse(payload []byte, compression IggyMessageCom
 	}, nil
 }
 
+// DeserializeFetchMessagesView reads the poll response metadata and leaves the messages in the payload to be read lazily.
+func DeserializeFetchMessagesView(payload []byte, compression IggyMessageCompression) *PolledMessageView {
+	if len(payload) < 16 {
+		return NewPolledMessageView(0, 0, 0, nil, compression)
+	}
+
+	partitionId := binary.LittleEndian.Uint32(payload[0:4])
+	currentOffset := binary.LittleEndian.Uint64(payload[4:12])
+	messagesCount := binary.LittleEndian.Uint32(payload[12:16])
+	return NewPolledMessageView(partitionId, currentOffset, messagesCount, payload[16:], compression)
+}
+
 func DeserializeTopics(payload []byte) ([]Topic, error) {
 	topics := make([]Topic, 0)
 	length := len(payload)
@@ -182,10 +222,13 @@ func DeserializeTopic(payload []byte) (*TopicDetails, error) {
 		return &TopicDetails{}, err
 	}
 
-	partitions := make([]PartitionContract, 0)
+	partitions := make([]PartitionContract, 0, (len(payload)-position)/partitionSize)
 	length := len(payload)
 
 	for position < length {
+		if length-position < partitionSize {
+			return &TopicDetails{}, ierror.InvalidBytesResponse
+		}
 		partition, readBytes := DeserializePartition(payload, position)
 		partitions = append(partitions, partition)
 		position += readBytes
@@ -197,21 +240,27 @@ func DeserializeTopic(payload []byte) (*TopicDetails, error) {
 }
 
 func DeserializeToTopic(payload []byte, position int) (Topic, int, error) {
+	if len(payload)-position < topicHeaderSize {
+		return Topic{}, 0, ierror.InvalidBytesResponse
+	}
 	topic := Topic{}
 	topic.Id = int(binary.LittleEndian.Uint32(payload[position : position+4]))
 	topic.CreatedAt = int(binary.LittleEndian.Uint64(payload[position+4 : position+12]))
 	topic.PartitionsCount = int(binary.LittleEndian.Uint32(payload[position+12 : position+16]))
-	topic.MessageExpiry = time.Microsecond * time.Duration(int(binary.LittleEndian.Uint64(payload[position+16:position+24])))
-	topic.CompressionAlgorithm = payload[position+24]
-	topic.MaxTopicSize = binary.LittleEndian.Uint64(payload[position+25 : position+33])
+	topic.MessageExpiry = IggyExpiry(binary.LittleEndian.Uint64(payload[position+16 : position+24]))
+	topic.CompressionAlgorithm = CompressionAlgorithm(payload[position+24])
+	topic.MaxTopicSize = MaxTopicSize(binary.LittleEndian.Uint64(payload[position+25 : position+33]))
 	topic.ReplicationFactor = payload[position+33]
 	topic.SizeBytes = binary.LittleEndian.Uint64(payload[position+34 : position+42])
 	topic.MessagesCount = binary.LittleEndian.Uint64(payload[position+42 : position+50])
 
 	nameLength := int(payload[position+50])
+	if len(payload)-position < topicHeaderSize+nameLength {
+		return Topic{}, 0, ierror.InvalidBytesResponse
+	}
 	topic.Name = string(payload[position+51 : position+51+nameLength])
 
-	readBytes := 4 + 8 + 4 + 8 + 8 + 8 + 8 + 1 + 1 + 1 + nameLength
+	readBytes := topicHeaderSize + nameLength
 	return topic, readBytes, nil
 }
 
@@ -222,7 +271,7 @@ func DeserializePartition(payload []byte, position int) (PartitionContract, int)
 	currentOffset := binary.LittleEndian.Uint64(payload[position+16 : position+24])
 	sizeBytes := binary.LittleEndian.Uint64(payload[position+24 : position+32])
 	messagesCount := binary.LittleEndian.Uint64(payload[position+32 : position+40])
-	readBytes := 4 + 4 + 8 + 8 + 8 + 8
+	readBytes := partitionSize
 
 	partition := PartitionContract{
 		Id:            id,
@@ -236,29 +285,39 @@ func DeserializePartition(payload []byte, position int) (PartitionContract, int)
 	return partition, readBytes
 }
 
-func DeserializeConsumerGroups(payload []byte) []ConsumerGroup {
+const consumerGroupHeaderSize = 4 + 4 + 4 + 1
+
+func DeserializeConsumerGroups(payload []byte) ([]ConsumerGroup, error) {
 	var consumerGroups []ConsumerGroup
 	length := len(payload)
 	position := 0
 
 	for position < length {
-		// use slices
-		consumerGroup, readBytes := DeserializeToConsumerGroup(payload, position)
+		consumerGroup, readBytes, err := DeserializeToConsumerGroup(payload, position)
+		if err != nil {
+			return nil, err
+		}
 		consumerGroups = append(consumerGroups, *consumerGroup)
 		position += readBytes
 	}
 
-	return consumerGroups
+	return consumerGroups, nil
 }
 
-func DeserializeToConsumerGroup(payload []byte, position int) (*ConsumerGroup, int) {
+func DeserializeToConsumerGroup(payload []byte, position int) (*ConsumerGroup, int, error) {
+	if len(payload)-position < consumerGroupHeaderSize {
+		return nil, 0, ierror.InvalidBytesResponse
+	}
 	id := int(binary.LittleEndian.Uint32(payload[position : position+4]))
 	partitionsCount := int(binary.LittleEndian.Uint32(payload[position+4 : position+8]))
 	membersCount := int(binary.LittleEndian.Uint32(payload[position+8 : position+12]))
 	nameLength := int(payload[position+12])
+	if len(payload)-position < consumerGroupHeaderSize+nameLength {
+		return nil, 0, ierror.InvalidBytesResponse
+	}
 	name := string(payload[position+13 : position+13+nameLength])
 
-	readBytes := 12 + 1 + nameLength
+	readBytes := consumerGroupHeaderSize + nameLength
 
 	consumerGroup := ConsumerGroup{
 		Id:              id,
@@ -267,16 +326,56 @@ func DeserializeToConsumerGroup(payload []byte, position int) (*ConsumerGroup, i
 		Name:            name,
 	}
 
-	return &consumerGroup, readBytes
+	return &consumerGroup, readBytes, nil
 }
 
-func DeserializeConsumerGroup(payload []byte) *ConsumerGroupDetails {
-	consumerGroup, _ := DeserializeToConsumerGroup(payload, 0)
-	// TODO: implement logic to deserialize the members.
+// DeserializeConsumerGroup reads the consumer group followed by its members, the clients which joined it,
+// with the partitions assigned to each of them.
+func DeserializeConsumerGroup(payload []byte) (*ConsumerGroupDetails, error) {
+	consumerGroup, position, err := DeserializeToConsumerGroup(payload, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each member takes at least 8 bytes, whatever the count announced in the header.
+	members := make([]ConsumerGroupMember, 0, min(consumerGroup.MembersCount, (len(payload)-position)/8))
+	for position < len(payload) {
+		member, readBytes, err := deserializeToConsumerGroupMember(payload, position)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+		position += readBytes
+	}
+
 	return &ConsumerGroupDetails{
 		ConsumerGroup: *consumerGroup,
-		Members:       nil,
+		Members:       members,
+	}, nil
+}
+
+func deserializeToConsumerGroupMember(payload []byte, position int) (ConsumerGroupMember, int, error) {
+	if len(payload)-position < 8 {
+		return ConsumerGroupMember{}, 0, ierror.InvalidBytesResponse
+	}
+	id := int(binary.LittleEndian.Uint32(payload[position : position+4]))
+	partitionsCount := int(binary.LittleEndian.Uint32(payload[position+4 : position+8]))
+	position += 8
+	if (len(payload)-position)/4 < partitionsCount {
+		return ConsumerGroupMember{}, 0, ierror.InvalidBytesResponse
+	}
+
+	partitions := make([]int, partitionsCount)
+	for i := range partitions {
+		partitions[i] = int(binary.LittleEndian.Uint32(payload[position : position+4]))
+		position += 4
 	}
+
+	return ConsumerGroupMember{
+		ID:              id,
+		PartitionsCount: partitionsCount,
+		Partitions:      partitions,
+	}, 8 + 4*partitionsCount, nil
 }
 
 func DeserializeUsers(payload []byte) ([]UserInfo, error) {
@@ -495,23 +594,21 @@ func MapClientInfo(payload []byte, position int) (ClientInfo, int) {
 
 func DeserializeClient(payload []byte) *ClientInfoDetails {
 	clientInfo, position := MapClientInfo(payload, 0)
-	consumerGroups := make([]ConsumerGroupInfo, clientInfo.ConsumerGroupsCount)
+	consumerGroups := make([]ConsumerGroupInfo, 0, clientInfo.ConsumerGroupsCount)
 	length := len(payload)
 
-	for position < length {
-		for i := uint32(0); i < clientInfo.ConsumerGroupsCount; i++ {
-			streamId := int32(binary.LittleEndian.Uint32(payload[position : position+4]))
-			topicId := int32(binary.LittleEndian.Uint32(payload[position+4 : position+8]))
-			consumerGroupId := int32(binary.LittleEndian.Uint32(payload[position+8 : position+12]))
-
-			consumerGroup := ConsumerGroupInfo{
-				StreamId:        int(streamId),
-				TopicId:         int(topicId),
-				ConsumerGroupId: int(consumerGroupId),
-			}
-			consumerGroups = append(consumerGroups, consumerGroup)
-			position += 12
+	for i := uint32(0); i < clientInfo.ConsumerGroupsCount && position+12 <= length; i++ {
+		streamId := int32(binary.LittleEndian.Uint32(payload[position : position+4]))
+		topicId := int32(binary.LittleEndian.Uint32(payload[position+4 : position+8]))
+		consumerGroupId := int32(binary.LittleEndian.Uint32(payload[position+8 : position+12]))
+
+		consumerGroup := ConsumerGroupInfo{
+			StreamId:        int(streamId),
+			TopicId:         int(topicId),
+			ConsumerGroupId: int(consumerGroupId),
 		}
+		consumerGroups = append(consumerGroups, consumerGroup)
+		position += 12
 	}
 	return &ClientInfoDetails{
 		ClientInfo:     clientInfo,
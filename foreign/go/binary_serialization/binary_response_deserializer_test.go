@@ -0,0 +1,301 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package binaryserialization
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+	"github.com/klauspost/compress/s2"
+)
+
+func fetchMessagesResponse(messages ...iggcon.IggyMessage) []byte {
+	payload := make([]byte, 16)
+	binary.LittleEndian.PutUint32(payload[0:4], 7)
+	binary.LittleEndian.PutUint64(payload[4:12], 42)
+	binary.LittleEndian.PutUint32(payload[12:16], uint32(len(messages)))
+	for _, message := range messages {
+		payload = append(payload, message.Header.ToBytes()...)
+		payload = append(payload, message.Payload...)
+		payload = append(payload, message.UserHeaders...)
+	}
+	return payload
+}
+
+func TestDeserializeFetchMessagesView_ReadsMessagesInPlace(t *testing.T) {
+	key, _ := iggcon.NewHeaderKey("key")
+	first, _ := iggcon.NewIggyMessage([]byte("first"), iggcon.WithUserHeaders(map[iggcon.HeaderKey]iggcon.HeaderValue{
+		key: {Kind: iggcon.String, Value: []byte("value")},
+	}))
+	first.Header.Offset, first.Header.Timestamp = 10, 20
+	second, _ := iggcon.NewIggyMessage([]byte("second"))
+	second.Header.Offset = 11
+	payload := fetchMessagesResponse(first, second)
+
+	view := DeserializeFetchMessagesView(payload, iggcon.MESSAGE_COMPRESSION_NONE)
+	if view.PartitionId != 7 || view.CurrentOffset != 42 || view.MessageCount != 2 {
+		t.Fatalf("unexpected metadata: %+v", view)
+	}
+
+	var views []iggcon.IggyMessageView
+	for message := range view.All() {
+		views = append(views, message)
+	}
+	if len(views) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(views))
+	}
+	if header := views[0].Header(); header.Offset() != 10 || header.Timestamp() != 20 || header.ToHeader() != first.Header {
+		t.Errorf("unexpected header: %+v", header.ToHeader())
+	}
+	if !bytes.Equal(views[0].Payload(), first.Payload) || !bytes.Equal(views[0].UserHeaders(), first.UserHeaders) {
+		t.Errorf("unexpected payload %q or user headers %v", views[0].Payload(), views[0].UserHeaders())
+	}
+	if headers, err := views[0].UserHeadersMap(); err != nil || string(headers[key].Value) != "value" {
+		t.Errorf("unexpected user headers %v: %v", headers, err)
+	}
+	if views[1].UserHeaders() != nil {
+		t.Errorf("expected no user headers, got %v", views[1].UserHeaders())
+	}
+
+	// The payload is referenced in place while the copied out message is independent of the response buffer.
+	message, err := views[1].ToMessage()
+	if err != nil {
+		t.Fatalf("failed to copy the message: %v", err)
+	}
+	views[1].Payload()[0] = 'S'
+	if string(message.Payload) != "second" || payload[16+2*iggcon.MessageHeaderSize+len("first")+len(first.UserHeaders)] != 'S' {
+		t.Errorf("expected the view to reference the response buffer and the message to be a copy")
+	}
+}
+
+func TestDeserializeFetchMessagesView_StopsAtTruncatedMessage(t *testing.T) {
+	first, _ := iggcon.NewIggyMessage([]byte("first"))
+	second, _ := iggcon.NewIggyMessage([]byte("second"))
+	payload := fetchMessagesResponse(first, second)
+
+	count := 0
+	for range DeserializeFetchMessagesView(payload[:len(payload)-1], iggcon.MESSAGE_COMPRESSION_NONE).All() {
+		count++
+	}
+	if count != 1 {
+		t.Fatalf("expected the truncated message to be skipped, got %d messages", count)
+	}
+}
+
+func TestPolledMessageView_ToPolledMessageDecompresses(t *testing.T) {
+	message, _ := iggcon.NewIggyMessage(s2.Encode(nil, []byte("compressed")))
+	view := DeserializeFetchMessagesView(fetchMessagesResponse(message), iggcon.MESSAGE_COMPRESSION_S2)
+
+	polled, err := view.ToPolledMessage()
+	if err != nil {
+		t.Fatalf("failed to copy the messages: %v", err)
+	}
+	if len(polled.Messages) != 1 || string(polled.Messages[0].Payload) != "compressed" {
+		t.Fatalf("unexpected messages: %+v", polled.Messages)
+	}
+}
+
+func TestDeserializeClient_ReadsConsumerGroups(t *testing.T) {
+	payload := binary.LittleEndian.AppendUint32(nil, 5)
+	payload = binary.LittleEndian.AppendUint32(payload, 1)
+	payload = append(payload, 1)
+	payload = binary.LittleEndian.AppendUint32(payload, uint32(len("127.0.0.1:5000")))
+	payload = append(payload, "127.0.0.1:5000"...)
+	payload = binary.LittleEndian.AppendUint32(payload, 2)
+	for _, group := range [][3]uint32{{1, 2, 3}, {1, 2, 4}} {
+		for _, id := range group {
+			payload = binary.LittleEndian.AppendUint32(payload, id)
+		}
+	}
+
+	client := DeserializeClient(payload)
+	if client.ID != 5 || client.UserID != 1 || client.Transport != string(iggcon.Tcp) || client.Address != "127.0.0.1:5000" {
+		t.Errorf("unexpected client info: %+v", client.ClientInfo)
+	}
+	expected := []iggcon.ConsumerGroupInfo{
+		{StreamId: 1, TopicId: 2, ConsumerGroupId: 3},
+		{StreamId: 1, TopicId: 2, ConsumerGroupId: 4},
+	}
+	if !slices.Equal(client.ConsumerGroups, expected) {
+		t.Errorf("expected the consumer groups %v, got %v", expected, client.ConsumerGroups)
+	}
+}
+
+func appendStream(payload []byte, id uint32, name string, topicsCount uint32) []byte {
+	payload = binary.LittleEndian.AppendUint32(payload, id)
+	payload = binary.LittleEndian.AppendUint64(payload, 1000)
+	payload = binary.LittleEndian.AppendUint32(payload, topicsCount)
+	payload = binary.LittleEndian.AppendUint64(payload, 2048)
+	payload = binary.LittleEndian.AppendUint64(payload, 16)
+	payload = append(payload, byte(len(name)))
+	return append(payload, name...)
+}
+
+func appendTopic(payload []byte, id uint32, name string) []byte {
+	payload = binary.LittleEndian.AppendUint32(payload, id)
+	payload = binary.LittleEndian.AppendUint64(payload, 1000)
+	payload = binary.LittleEndian.AppendUint32(payload, 3)
+	payload = binary.LittleEndian.AppendUint64(payload, 60_000_000)
+	payload = append(payload, 2)
+	payload = binary.LittleEndian.AppendUint64(payload, 1<<30)
+	payload = append(payload, 1)
+	payload = binary.LittleEndian.AppendUint64(payload, 512)
+	payload = binary.LittleEndian.AppendUint64(payload, uint64(id)*10)
+	payload = append(payload, byte(len(name)))
+	return append(payload, name...)
+}
+
+func TestDeserializeStream_ReadsTopics(t *testing.T) {
+	const topicsCount = 3000
+	payload := appendStream(nil, 1, "stream", topicsCount)
+	for id := uint32(1); id <= topicsCount; id++ {
+		payload = appendTopic(payload, id, fmt.Sprintf("topic-%d", id))
+	}
+	if len(payload) <= 1<<16 {
+		t.Fatalf("expected a payload larger than 2^16 bytes, got %d", len(payload))
+	}
+
+	stream, err := DeserializeStream(payload)
+	if err != nil {
+		t.Fatalf("failed to deserialize the stream: %v", err)
+	}
+	if stream.Id != 1 || stream.Name != "stream" || stream.TopicsCount != topicsCount || len(stream.Topics) != topicsCount {
+		t.Fatalf("unexpected stream: %+v with %d topics", stream.Stream, len(stream.Topics))
+	}
+	expected := iggcon.Topic{
+		Id:                   topicsCount,
+		CreatedAt:            1000,
+		Name:                 fmt.Sprintf("topic-%d", topicsCount),
+		SizeBytes:            512,
+		MessageExpiry:        iggcon.ExpireAfter(time.Minute),
+		CompressionAlgorithm: 2,
+		MaxTopicSize:         1 << 30,
+		ReplicationFactor:    1,
+		MessagesCount:        topicsCount * 10,
+		PartitionsCount:      3,
+	}
+	if topic := stream.Topics[topicsCount-1]; topic != expected {
+		t.Fatalf("expected the last topic %+v, got %+v", expected, topic)
+	}
+}
+
+func TestDeserializeToTopic_KeepsSentinels(t *testing.T) {
+	tests := []struct {
+		expiry       iggcon.IggyExpiry
+		maxTopicSize iggcon.MaxTopicSize
+	}{
+		{expiry: iggcon.ExpiryNever, maxTopicSize: iggcon.MaxTopicSizeUnlimited},
+		{expiry: iggcon.ExpiryServerDefault, maxTopicSize: iggcon.MaxTopicSizeServerDefault},
+	}
+	for _, tt := range tests {
+		payload := appendTopic(nil, 1, "topic")
+		binary.LittleEndian.PutUint64(payload[16:24], uint64(tt.expiry))
+		binary.LittleEndian.PutUint64(payload[25:33], uint64(tt.maxTopicSize))
+
+		topic, _, err := DeserializeToTopic(payload, 0)
+		if err != nil {
+			t.Fatalf("failed to deserialize the topic: %v", err)
+		}
+		if topic.MessageExpiry != tt.expiry || topic.MaxTopicSize != tt.maxTopicSize {
+			t.Errorf("expected the expiry %d and the max size %d, got %d and %d", tt.expiry, tt.maxTopicSize, topic.MessageExpiry, topic.MaxTopicSize)
+		}
+	}
+}
+
+func TestDeserializeStreams_LargePayload(t *testing.T) {
+	const streamsCount = 1000
+	var payload []byte
+	for id := uint32(1); id <= streamsCount; id++ {
+		payload = appendStream(payload, id, strings.Repeat("s", 100)+strconv.Itoa(int(id)), 0)
+	}
+	if len(payload) <= 1<<16 {
+		t.Fatalf("expected a payload larger than 2^16 bytes, got %d", len(payload))
+	}
+
+	streams, err := DeserializeStreams(payload)
+	if err != nil {
+		t.Fatalf("failed to deserialize the streams: %v", err)
+	}
+	if len(streams) != streamsCount {
+		t.Fatalf("expected %d streams, got %d", streamsCount, len(streams))
+	}
+	for i, stream := range streams {
+		if stream.Id != i+1 || !strings.HasSuffix(stream.Name, strconv.Itoa(i+1)) || stream.SizeBytes != 2048 {
+			t.Fatalf("unexpected stream at %d: %+v", i, stream)
+		}
+	}
+}
+
+func TestDeserializeStream_TruncatedPayload(t *testing.T) {
+	payload := appendTopic(appendStream(nil, 1, "stream", 1), 1, "topic")
+	for _, truncated := range [][]byte{nil, payload[:20], payload[:len(payload)-1]} {
+		if _, err := DeserializeStream(truncated); !errors.Is(err, ierror.InvalidBytesResponse) {
+			t.Errorf("expected an invalid response error for %d bytes, got %v", len(truncated), err)
+		}
+	}
+
+	payload = appendStream(appendStream(nil, 1, "first", 0), 2, "second", 0)
+	for _, truncated := range [][]byte{payload[:20], payload[:len(payload)-1]} {
+		if _, err := DeserializeStreams(truncated); !errors.Is(err, ierror.InvalidBytesResponse) {
+			t.Errorf("expected an invalid response error for %d bytes, got %v", len(truncated), err)
+		}
+	}
+}
+
+func TestDeserializeConsumerGroup_ReadsMembers(t *testing.T) {
+	payload := binary.LittleEndian.AppendUint32(nil, 3)
+	payload = binary.LittleEndian.AppendUint32(payload, 3)
+	payload = binary.LittleEndian.AppendUint32(payload, 2)
+	payload = append(payload, byte(len("group")))
+	payload = append(payload, "group"...)
+	for _, member := range [][]uint32{{10, 1, 3}, {11, 2}} {
+		payload = binary.LittleEndian.AppendUint32(payload, member[0])
+		payload = binary.LittleEndian.AppendUint32(payload, uint32(len(member)-1))
+		for _, partition := range member[1:] {
+			payload = binary.LittleEndian.AppendUint32(payload, partition)
+		}
+	}
+
+	group, err := DeserializeConsumerGroup(payload)
+	if err != nil {
+		t.Fatalf("failed to deserialize the consumer group: %v", err)
+	}
+	expected := []iggcon.ConsumerGroupMember{
+		{ID: 10, PartitionsCount: 2, Partitions: []int{1, 3}},
+		{ID: 11, PartitionsCount: 1, Partitions: []int{2}},
+	}
+	if group.Name != "group" || group.MembersCount != 2 || !reflect.DeepEqual(group.Members, expected) {
+		t.Fatalf("unexpected consumer group: %+v", group)
+	}
+
+	for _, truncated := range [][]byte{payload[:10], payload[:len(payload)-2], payload[:len(payload)-4]} {
+		if _, err := DeserializeConsumerGroup(truncated); !errors.Is(err, ierror.InvalidBytesResponse) {
+			t.Errorf("expected an invalid response error for %d bytes, got %v", len(truncated), err)
+		}
+	}
+}
@@ -19,29 +19,22 @@ package binaryserialization
 
 import (
 	"encoding/binary"
-	"time"
 
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
 )
 
 type TcpCreateTopicRequest struct {
-	StreamId             iggcon.Identifier `json:"streamId"`
-	PartitionsCount      int               `json:"partitionsCount"`
-	CompressionAlgorithm uint8             `json:"compressionAlgorithm"`
-	MessageExpiry        time.Duration     `json:"messageExpiry"`
-	MaxTopicSize         uint64            `json:"maxTopicSize"`
-	Name                 string            `json:"name"`
-	ReplicationFactor    *uint8            `json:"replicationFactor"`
-	TopicId              *int              `json:"topicId"`
+	iggcon.TopicConfig
+	StreamId        iggcon.Identifier `json:"streamId"`
+	PartitionsCount uint32            `json:"partitionsCount"`
+	Name            string            `json:"name"`
+	TopicId         *int              `json:"topicId"`
 }
 
 func (request *TcpCreateTopicRequest) Serialize() []byte {
 	if request.TopicId == nil {
 		request.TopicId = new(int)
 	}
-	if request.ReplicationFactor == nil {
-		request.ReplicationFactor = new(uint8)
-	}
 
 	streamIdBytes := SerializeIdentifier(request.StreamId)
 	nameBytes := []byte(request.Name)
@@ -68,23 +61,23 @@ func (request *TcpCreateTopicRequest) Serialize() []byte {
 	position += 4
 
 	// PartitionsCount
-	binary.LittleEndian.PutUint32(bytes[position:], uint32(request.PartitionsCount))
+	binary.LittleEndian.PutUint32(bytes[position:], request.PartitionsCount)
 	position += 4
 
 	// CompressionAlgorithm
-	bytes[position] = request.CompressionAlgorithm
+	bytes[position] = request.CompressionAlgorithm.Code()
 	position++
 
 	// MessageExpiry
-	binary.LittleEndian.PutUint64(bytes[position:], uint64(request.MessageExpiry.Microseconds()))
+	binary.LittleEndian.PutUint64(bytes[position:], uint64(request.MessageExpiry))
 	position += 8
 
 	// MaxTopicSize
-	binary.LittleEndian.PutUint64(bytes[position:], request.MaxTopicSize)
+	binary.LittleEndian.PutUint64(bytes[position:], uint64(request.MaxTopicSize))
 	position += 8
 
 	// ReplicationFactor
-	bytes[position] = *request.ReplicationFactor
+	bytes[position] = request.ReplicationFactor
 	position++
 
 	// Name
@@ -0,0 +1,61 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package binaryserialization
+
+import (
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func TestSerialize_CreateTopic(t *testing.T) {
+	topicId := 1
+	request := TcpCreateTopicRequest{
+		TopicConfig: iggcon.TopicConfig{
+			CompressionAlgorithm: iggcon.CompressionGzip,
+			MessageExpiry:        iggcon.ExpireAfter(time.Millisecond),
+			MaxTopicSize:         iggcon.MaxTopicSizeUnlimited,
+			ReplicationFactor:    1,
+		},
+		StreamId:        iggcon.NewIdentifier(1),
+		PartitionsCount: 2,
+		Name:            "topic",
+		TopicId:         &topicId,
+	}
+
+	serialized := request.Serialize()
+
+	expected := []byte{
+		0x01,                   // StreamId Kind (NumericId)
+		0x04,                   // StreamId Length (4)
+		0x01, 0x00, 0x00, 0x00, // StreamId Value (1)
+		0x01, 0x00, 0x00, 0x00, // TopicId (1)
+		0x02, 0x00, 0x00, 0x00, // PartitionsCount (2)
+		0x02,                                           // Compression algorithm (gzip)
+		0xE8, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Message Expiry (1000 microseconds)
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, // Max Topic Size (unlimited)
+		0x01,                         // Replication factor
+		0x05,                         // Name Length (5)
+		0x74, 0x6F, 0x70, 0x69, 0x63, // Name ("topic")
+	}
+
+	if !areBytesEqual(serialized, expected) {
+		t.Errorf("Expected:\t%v\nGot:\t\t%v", expected, serialized)
+	}
+}
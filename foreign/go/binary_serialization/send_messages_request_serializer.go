@@ -19,6 +19,7 @@ package binaryserialization
 
 import (
 	"encoding/binary"
+	"math"
 
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
 	"github.com/klauspost/compress/s2"
@@ -29,33 +30,41 @@ type TcpSendMessagesRequest struct {
 	TopicId      iggcon.Identifier    `json:"topicId"`
 	Partitioning iggcon.Partitioning  `json:"partitioning"`
 	Messages     []iggcon.IggyMessage `json:"messages"`
+	// Checksums fills the checksum of the messages, computed once their payload is compressed.
+	Checksums bool `json:"checksums"`
 }
 
 const indexSize = 16
 
+// ZeroCopyPayloadSize is the size from which SerializeBuffers references the message payloads instead of copying them.
+const ZeroCopyPayloadSize = 4096
+
 func (request *TcpSendMessagesRequest) Serialize(compression iggcon.IggyMessageCompression) []byte {
-	for i, message := range request.Messages {
-		switch compression {
-		case iggcon.MESSAGE_COMPRESSION_S2:
-			if len(message.Payload) < 32 {
-				break
-			}
-			request.Messages[i].Payload = s2.Encode(nil, message.Payload)
-			message.Header.PayloadLength = uint32(len(message.Payload))
-		case iggcon.MESSAGE_COMPRESSION_S2_BETTER:
-			if len(message.Payload) < 32 {
-				break
-			}
-			request.Messages[i].Payload = s2.EncodeBetter(nil, message.Payload)
-			message.Header.PayloadLength = uint32(len(message.Payload))
-		case iggcon.MESSAGE_COMPRESSION_S2_BEST:
-			if len(message.Payload) < 32 {
-				break
-			}
-			request.Messages[i].Payload = s2.EncodeBest(nil, message.Payload)
-			message.Header.PayloadLength = uint32(len(message.Payload))
-		}
-	}
+	buffers := request.serialize(compression, 0, math.MaxInt, func(size int) []byte {
+		return make([]byte, size)
+	})
+	return buffers[0]
+}
+
+// SerializeBuffers serializes the request for a vectored write, without copying the payloads of at least
+// ZeroCopyPayloadSize bytes, which must then be left unchanged until the buffers are written.
+// The metadata, the indexes, the message headers and the smaller payloads are written into a single buffer
+// obtained from allocate, with headroom bytes left free at its start for the caller to fill.
+func (request *TcpSendMessagesRequest) SerializeBuffers(
+	compression iggcon.IggyMessageCompression,
+	headroom int,
+	allocate func(size int) []byte,
+) [][]byte {
+	return request.serialize(compression, headroom, ZeroCopyPayloadSize, allocate)
+}
+
+func (request *TcpSendMessagesRequest) serialize(
+	compression iggcon.IggyMessageCompression,
+	headroom int,
+	zeroCopyPayloadSize int,
+	allocate func(size int) []byte,
+) [][]byte {
+	request.compress(compression)
 
 	streamIdFieldSize := 2 + request.StreamId.Length
 	topicIdFieldSize := 2 + request.TopicId.Length
@@ -68,64 +77,97 @@ func (request *TcpSendMessagesRequest) Serialize(compression iggcon.IggyMessageC
 		partitioningFieldSize +
 		messagesCountFieldSize
 	indexesSize := messageCount * indexSize
-	messageBytesCount := calculateMessageBytesCount(request.Messages)
-	totalSize := metadataLenFieldSize +
+	copiedSize := 0
+	zeroCopyCount := 0
+	for _, message := range request.Messages {
+		copiedSize += iggcon.MessageHeaderSize + len(message.UserHeaders)
+		if len(message.Payload) < zeroCopyPayloadSize {
+			copiedSize += len(message.Payload)
+		} else {
+			zeroCopyCount++
+		}
+	}
+	totalSize := headroom +
+		metadataLenFieldSize +
 		streamIdFieldSize +
 		topicIdFieldSize +
 		partitioningFieldSize +
 		messagesCountFieldSize +
 		indexesSize +
-		messageBytesCount
+		copiedSize
 
-	bytes := make([]byte, totalSize)
+	bytes := allocate(totalSize)
+	buffers := make([][]byte, 0, 1+2*zeroCopyCount)
 
-	position := 0
+	position := headroom
 
 	//metadata
-	binary.LittleEndian.PutUint32(bytes[:4], uint32(metadataLen))
-	position = 4
+	binary.LittleEndian.PutUint32(bytes[position:position+4], uint32(metadataLen))
+	position += 4
 	//ids
-	copy(bytes[position:position+streamIdFieldSize], SerializeIdentifier(request.StreamId))
-	copy(bytes[position+streamIdFieldSize:position+streamIdFieldSize+topicIdFieldSize], SerializeIdentifier(request.TopicId))
-	position += streamIdFieldSize + topicIdFieldSize
+	position += copy(bytes[position:position+streamIdFieldSize], SerializeIdentifier(request.StreamId))
+	position += copy(bytes[position:position+topicIdFieldSize], SerializeIdentifier(request.TopicId))
 
 	//partitioning
 	bytes[position] = byte(request.Partitioning.Kind)
 	bytes[position+1] = byte(request.Partitioning.Length)
-	copy(bytes[position+2:position+partitioningFieldSize], []byte(request.Partitioning.Value))
+	copy(bytes[position+2:position+partitioningFieldSize], request.Partitioning.Value)
 	position += partitioningFieldSize
 	binary.LittleEndian.PutUint32(bytes[position:position+4], uint32(messageCount))
 	position += 4
 
 	currentIndexPosition := position
-	for i := 0; i < indexesSize; i++ {
-		bytes[position+i] = 0
-	}
+	clear(bytes[position : position+indexesSize])
 	position += indexesSize
 
+	// segmentStart is the position in bytes of the data not yet added to the buffers.
+	segmentStart := 0
 	msgSize := uint32(0)
 	for _, message := range request.Messages {
-		copy(bytes[position:position+iggcon.MessageHeaderSize], message.Header.ToBytes())
-		copy(bytes[position+iggcon.MessageHeaderSize:position+iggcon.MessageHeaderSize+int(message.Header.PayloadLength)], message.Payload)
-		position += iggcon.MessageHeaderSize + int(message.Header.PayloadLength)
-		copy(bytes[position:position+int(message.Header.UserHeaderLength)], message.UserHeaders)
-		position += int(message.Header.UserHeaderLength)
+		if request.Checksums {
+			message.Header.Checksum = message.CalculateChecksum()
+		}
+		message.Header.PutBytes(bytes[position : position+iggcon.MessageHeaderSize])
+		position += iggcon.MessageHeaderSize
+		if len(message.Payload) < zeroCopyPayloadSize {
+			position += copy(bytes[position:], message.Payload)
+		} else {
+			buffers = append(buffers, bytes[segmentStart:position], message.Payload)
+			segmentStart = position
+		}
+		position += copy(bytes[position:], message.UserHeaders)
 
 		msgSize += iggcon.MessageHeaderSize + message.Header.PayloadLength + message.Header.UserHeaderLength
 
-		binary.LittleEndian.PutUint32(bytes[currentIndexPosition:currentIndexPosition+4], 0)
-		binary.LittleEndian.PutUint32(bytes[currentIndexPosition+4:currentIndexPosition+8], uint32(msgSize))
-		binary.LittleEndian.PutUint32(bytes[currentIndexPosition+8:currentIndexPosition+12], 0)
+		binary.LittleEndian.PutUint32(bytes[currentIndexPosition+4:currentIndexPosition+8], msgSize)
 		currentIndexPosition += indexSize
 	}
+	if segmentStart < position || len(buffers) == 0 {
+		buffers = append(buffers, bytes[segmentStart:position])
+	}
 
-	return bytes
+	return buffers
 }
 
-func calculateMessageBytesCount(messages []iggcon.IggyMessage) int {
-	count := 0
-	for _, msg := range messages {
-		count += iggcon.MessageHeaderSize + len(msg.Payload) + len(msg.UserHeaders)
+// compress replaces the payloads of at least 32 bytes by their compressed form.
+func (request *TcpSendMessagesRequest) compress(compression iggcon.IggyMessageCompression) {
+	var encode func(dst, src []byte) []byte
+	switch compression {
+	case iggcon.MESSAGE_COMPRESSION_S2:
+		encode = s2.Encode
+	case iggcon.MESSAGE_COMPRESSION_S2_BETTER:
+		encode = s2.EncodeBetter
+	case iggcon.MESSAGE_COMPRESSION_S2_BEST:
+		encode = s2.EncodeBest
+	default:
+		return
+	}
+	for i := range request.Messages {
+		message := &request.Messages[i]
+		if len(message.Payload) < 32 {
+			continue
+		}
+		message.Payload = encode(nil, message.Payload)
+		message.Header.PayloadLength = uint32(len(message.Payload))
 	}
-	return count
 }
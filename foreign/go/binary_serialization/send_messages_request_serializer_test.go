@@ -18,6 +18,8 @@
 package binaryserialization
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
@@ -64,6 +66,51 @@ func TestSerialize_SendMessagesRequest(t *testing.T) {
 	}
 }
 
+func TestSerializeBuffers_ReferencesLargePayloads(t *testing.T) {
+	small := generateTestMessage("data1")
+	large := generateTestMessage(strings.Repeat("x", ZeroCopyPayloadSize))
+	request := TcpSendMessagesRequest{
+		StreamId:     iggcon.NewIdentifier(1),
+		TopicId:      iggcon.NewIdentifier(2),
+		Partitioning: iggcon.PartitionId(1),
+		Messages:     []iggcon.IggyMessage{small, large, small},
+	}
+
+	const headroom = 8
+	buffers := request.SerializeBuffers(iggcon.MESSAGE_COMPRESSION_NONE, headroom, func(size int) []byte {
+		return make([]byte, size)
+	})
+	if len(buffers) != 3 {
+		t.Fatalf("expected the large payload to be split into its own buffer, got %d buffers", len(buffers))
+	}
+	if &buffers[1][0] != &large.Payload[0] {
+		t.Fatal("expected the large payload to be referenced rather than copied")
+	}
+
+	serialized := bytes.Join(buffers, nil)[headroom:]
+	if expected := request.Serialize(iggcon.MESSAGE_COMPRESSION_NONE); !bytes.Equal(serialized, expected) {
+		t.Errorf("Serialized bytes are incorrect. \nExpected:\t%v\nGot:\t\t%v", expected, serialized)
+	}
+}
+
+func TestSerialize_CompressedPayloadLength(t *testing.T) {
+	request := TcpSendMessagesRequest{
+		StreamId:     iggcon.NewIdentifier(1),
+		TopicId:      iggcon.NewIdentifier(2),
+		Partitioning: iggcon.PartitionId(1),
+		Messages:     []iggcon.IggyMessage{generateTestMessage(strings.Repeat("x", 1000))},
+	}
+
+	serialized := request.Serialize(iggcon.MESSAGE_COMPRESSION_S2)
+	message := request.Messages[0]
+	if int(message.Header.PayloadLength) != len(message.Payload) || len(message.Payload) >= 1000 {
+		t.Fatalf("expected the header to hold the compressed payload length %d, got %d", len(message.Payload), message.Header.PayloadLength)
+	}
+	if !bytes.HasSuffix(serialized, append(message.Payload, message.UserHeaders...)) {
+		t.Error("expected the serialized request to end with the compressed payload and the user headers")
+	}
+}
+
 func createDefaultMessageHeaders() map[iggcon.HeaderKey]iggcon.HeaderValue {
 	return map[iggcon.HeaderKey]iggcon.HeaderValue{
 		{Value: "HeaderKey1"}: {Kind: iggcon.String, Value: []byte("Value 1")},
@@ -22,6 +22,7 @@ import (
 	"math"
 
 	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
 type TcpStats struct {
@@ -48,9 +49,14 @@ const (
 	messagesCountPos       = 92
 	clientsCountPos        = 100
 	consumerGroupsCountPos = 104
+	// cacheMetricsSize is the size of the stream, topic and partition IDs followed by the hits, misses and hit ratio.
+	cacheMetricsSize = 4 + 4 + 4 + 8 + 8 + 4
 )
 
 func (stats *TcpStats) Deserialize(payload []byte) error {
+	if len(payload) < consumerGroupsCountPos+4 {
+		return ierror.InvalidBytesResponse
+	}
 	stats.ProcessId = int(binary.LittleEndian.Uint32(payload[processIDPos : processIDPos+4]))
 	stats.CpuUsage = math.Float32frombits(binary.LittleEndian.Uint32(payload[cpuUsagePos : cpuUsagePos+4]))
 	stats.TotalCpuUsage = math.Float32frombits(binary.LittleEndian.Uint32(payload[totalCpuUsagePos : totalCpuUsagePos+4]))
@@ -71,20 +77,70 @@ func (stats *TcpStats) Deserialize(payload []byte) error {
 	stats.ConsumerGroupsCount = int(binary.LittleEndian.Uint32(payload[consumerGroupsCountPos : consumerGroupsCountPos+4]))
 
 	position := consumerGroupsCountPos + 4
-	hostnameLength := int(binary.LittleEndian.Uint32(payload[position : position+4]))
-	stats.Hostname = string(payload[position+4 : position+4+hostnameLength])
-	position += 4 + hostnameLength
+	var err error
+	if stats.Hostname, position, err = deserializeStatsString(payload, position); err != nil {
+		return err
+	}
+	if stats.OsName, position, err = deserializeStatsString(payload, position); err != nil {
+		return err
+	}
+	if stats.OsVersion, position, err = deserializeStatsString(payload, position); err != nil {
+		return err
+	}
+	if stats.KernelVersion, position, err = deserializeStatsString(payload, position); err != nil {
+		return err
+	}
 
-	osNameLength := int(binary.LittleEndian.Uint32(payload[position : position+4]))
-	stats.OsName = string(payload[position+4 : position+4+osNameLength])
-	position += 4 + osNameLength
+	// The server version is only sent by the newer servers.
+	if position == len(payload) {
+		return nil
+	}
+	if stats.IggyServerVersion, position, err = deserializeStatsString(payload, position); err != nil {
+		return err
+	}
 
-	osVersionLength := int(binary.LittleEndian.Uint32(payload[position : position+4]))
-	stats.OsVersion = string(payload[position+4 : position+4+osVersionLength])
-	position += 4 + osVersionLength
+	if position+4 > len(payload) {
+		return nil
+	}
+	stats.IggyServerSemver = iggcon.SemanticVersion(binary.LittleEndian.Uint32(payload[position : position+4]))
+	position += 4
 
-	kernelVersionLength := int(binary.LittleEndian.Uint32(payload[position : position+4]))
-	stats.KernelVersion = string(payload[position+4 : position+4+kernelVersionLength])
+	// The cache metrics are only sent by the servers reporting their semantic version.
+	if position+4 > len(payload) {
+		return nil
+	}
+	count := int(binary.LittleEndian.Uint32(payload[position : position+4]))
+	position += 4
+	if (len(payload)-position)/cacheMetricsSize < count {
+		return ierror.InvalidBytesResponse
+	}
+	stats.CacheMetrics = make(map[iggcon.CacheMetricsKey]iggcon.CacheMetrics, count)
+	for range count {
+		key := iggcon.CacheMetricsKey{
+			StreamId:    binary.LittleEndian.Uint32(payload[position : position+4]),
+			TopicId:     binary.LittleEndian.Uint32(payload[position+4 : position+8]),
+			PartitionId: binary.LittleEndian.Uint32(payload[position+8 : position+12]),
+		}
+		stats.CacheMetrics[key] = iggcon.CacheMetrics{
+			Hits:     binary.LittleEndian.Uint64(payload[position+12 : position+20]),
+			Misses:   binary.LittleEndian.Uint64(payload[position+20 : position+28]),
+			HitRatio: math.Float32frombits(binary.LittleEndian.Uint32(payload[position+28 : position+32])),
+		}
+		position += cacheMetricsSize
+	}
 
 	return nil
 }
+
+// deserializeStatsString reads a string prefixed with its length, returning the position after it.
+func deserializeStatsString(payload []byte, position int) (string, int, error) {
+	if position+4 > len(payload) {
+		return "", position, ierror.InvalidBytesResponse
+	}
+	length := int(binary.LittleEndian.Uint32(payload[position : position+4]))
+	position += 4
+	if position+length > len(payload) {
+		return "", position, ierror.InvalidBytesResponse
+	}
+	return string(payload[position : position+length]), position + length, nil
+}
@@ -18,7 +18,14 @@
 package binaryserialization
 
 import (
+	"encoding/binary"
+	"errors"
+	"maps"
+	"math"
 	"testing"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
 func TestDeserialize(t *testing.T) {
@@ -125,3 +132,80 @@ func TestDeserialize(t *testing.T) {
 		t.Errorf("KernelVersion is incorrect. Expected: \"6.4.6-76060406-generic\", Got: \"%s\"", stats.KernelVersion)
 	}
 }
+
+// statsPayload returns the stats with empty fields followed by the given strings, each prefixed with its length.
+func statsPayload(strings ...string) []byte {
+	payload := make([]byte, consumerGroupsCountPos+4)
+	for _, value := range strings {
+		payload = binary.LittleEndian.AppendUint32(payload, uint32(len(value)))
+		payload = append(payload, value...)
+	}
+	return payload
+}
+
+func TestDeserialize_ServerVersion(t *testing.T) {
+	payload := binary.LittleEndian.AppendUint32(statsPayload("host", "os", "1.0", "6.4", "0.4.300"), 4300)
+
+	var stats TcpStats
+	if err := stats.Deserialize(payload); err != nil {
+		t.Fatalf("Deserialization error: %v", err)
+	}
+	if stats.IggyServerVersion != "0.4.300" || stats.IggyServerSemver != iggcon.NewSemanticVersion(0, 4, 300) {
+		t.Errorf("unexpected server version %q and semver %v", stats.IggyServerVersion, stats.IggyServerSemver)
+	}
+
+	// The older servers do not send their version.
+	stats = TcpStats{}
+	if err := stats.Deserialize(statsPayload("host", "os", "1.0", "6.4")); err != nil {
+		t.Fatalf("Deserialization error: %v", err)
+	}
+	if stats.KernelVersion != "6.4" || stats.IggyServerVersion != "" || stats.IggyServerSemver != 0 {
+		t.Errorf("unexpected kernel version %q, server version %q and semver %v", stats.KernelVersion, stats.IggyServerVersion, stats.IggyServerSemver)
+	}
+}
+
+func TestDeserialize_TruncatedPayload(t *testing.T) {
+	payload := statsPayload("host", "os", "1.0", "6.4")
+	for _, truncated := range [][]byte{nil, payload[:consumerGroupsCountPos], payload[:len(payload)-1]} {
+		var stats TcpStats
+		if err := stats.Deserialize(truncated); !errors.Is(err, ierror.InvalidBytesResponse) {
+			t.Errorf("expected an invalid response error for %d bytes, got %v", len(truncated), err)
+		}
+	}
+}
+
+func TestDeserialize_CacheMetrics(t *testing.T) {
+	payload := binary.LittleEndian.AppendUint32(statsPayload("host", "os", "1.0", "6.4", "0.5.0"), 5000)
+	payload = binary.LittleEndian.AppendUint32(payload, 2)
+	for _, partition := range []uint32{1, 2} {
+		payload = binary.LittleEndian.AppendUint32(payload, 10)
+		payload = binary.LittleEndian.AppendUint32(payload, 20)
+		payload = binary.LittleEndian.AppendUint32(payload, partition)
+		payload = binary.LittleEndian.AppendUint64(payload, 3*uint64(partition))
+		payload = binary.LittleEndian.AppendUint64(payload, 1)
+		payload = binary.LittleEndian.AppendUint32(payload, math.Float32bits(0.75))
+	}
+
+	var stats TcpStats
+	if err := stats.Deserialize(payload); err != nil {
+		t.Fatalf("Deserialization error: %v", err)
+	}
+	expected := map[iggcon.CacheMetricsKey]iggcon.CacheMetrics{
+		{StreamId: 10, TopicId: 20, PartitionId: 1}: {Hits: 3, Misses: 1, HitRatio: 0.75},
+		{StreamId: 10, TopicId: 20, PartitionId: 2}: {Hits: 6, Misses: 1, HitRatio: 0.75},
+	}
+	if !maps.Equal(stats.CacheMetrics, expected) {
+		t.Errorf("expected the cache metrics %v, got %v", expected, stats.CacheMetrics)
+	}
+
+	// The servers reporting their semantic version without the cache metrics are still supported.
+	stats = TcpStats{}
+	if err := stats.Deserialize(payload[:len(payload)-4-2*cacheMetricsSize]); err != nil || stats.CacheMetrics != nil {
+		t.Errorf("expected no cache metrics without error, got %v (%v)", stats.CacheMetrics, err)
+	}
+
+	stats = TcpStats{}
+	if err := stats.Deserialize(payload[:len(payload)-1]); !errors.Is(err, ierror.InvalidBytesResponse) {
+		t.Errorf("expected an invalid response error for truncated cache metrics, got %v", err)
+	}
+}
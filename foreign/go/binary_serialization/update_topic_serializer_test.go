@@ -25,11 +25,13 @@ import (
 
 func TestSerialize_UpdateTopic(t *testing.T) {
 	request := TcpUpdateTopicRequest{
-		StreamId:      iggcon.NewIdentifier("stream"),
-		TopicId:       iggcon.NewIdentifier(1),
-		Name:          "update_topic",
-		MessageExpiry: 100000,
-		MaxTopicSize:  100,
+		TopicConfig: iggcon.TopicConfig{
+			MessageExpiry: 100,
+			MaxTopicSize:  100,
+		},
+		StreamId: iggcon.NewIdentifier("stream"),
+		TopicId:  iggcon.NewIdentifier(1),
+		Name:     "update_topic",
 	}
 
 	serialized1 := request.Serialize()
@@ -41,7 +43,7 @@ func TestSerialize_UpdateTopic(t *testing.T) {
 		0x01,                   // TopicId Kind (NumericId)
 		0x04,                   // TopicId Length (4)
 		0x01, 0x00, 0x00, 0x00, // TopicId Value (1)
-		0x00,                                           // compression algorithm
+		0x01,                                           // compression algorithm (none)
 		0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Message Expiry (100)
 		0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Max Topic Size (100)
 		0x00,                                                                   // Replication factor
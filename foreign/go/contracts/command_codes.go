@@ -17,11 +17,14 @@
 
 package iggcon
 
+import "fmt"
+
 type CommandCode int
 
 const (
 	PingCode                 CommandCode = 1
 	GetStatsCode             CommandCode = 10
+	GetSnapshotFileCode      CommandCode = 11
 	GetMeCode                CommandCode = 20
 	GetClientCode            CommandCode = 21
 	GetClientsCode           CommandCode = 22
@@ -40,20 +43,25 @@ const (
 	LoginWithAccessTokenCode CommandCode = 44
 	PollMessagesCode         CommandCode = 100
 	SendMessagesCode         CommandCode = 101
+	FlushUnsavedBufferCode   CommandCode = 102
 	GetOffsetCode            CommandCode = 120
 	StoreOffsetCode          CommandCode = 121
+	DeleteOffsetCode         CommandCode = 122
 	GetStreamCode            CommandCode = 200
 	GetStreamsCode           CommandCode = 201
 	CreateStreamCode         CommandCode = 202
 	DeleteStreamCode         CommandCode = 203
 	UpdateStreamCode         CommandCode = 204
+	PurgeStreamCode          CommandCode = 205
 	GetTopicCode             CommandCode = 300
 	GetTopicsCode            CommandCode = 301
 	CreateTopicCode          CommandCode = 302
 	DeleteTopicCode          CommandCode = 303
 	UpdateTopicCode          CommandCode = 304
+	PurgeTopicCode           CommandCode = 305
 	CreatePartitionsCode     CommandCode = 402
 	DeletePartitionsCode     CommandCode = 403
+	DeleteSegmentsCode       CommandCode = 503
 	GetGroupCode             CommandCode = 600
 	GetGroupsCode            CommandCode = 601
 	CreateGroupCode          CommandCode = 602
@@ -62,6 +70,63 @@ const (
 	LeaveGroupCode           CommandCode = 605
 )
 
+var commandNames = map[CommandCode]string{
+	PingCode:                 "ping",
+	GetStatsCode:             "stats",
+	GetSnapshotFileCode:      "snapshot",
+	GetMeCode:                "me",
+	GetClientCode:            "client.get",
+	GetClientsCode:           "client.list",
+	GetUserCode:              "user.get",
+	GetUsersCode:             "user.list",
+	CreateUserCode:           "user.create",
+	DeleteUserCode:           "user.delete",
+	UpdateUserCode:           "user.update",
+	UpdatePermissionsCode:    "user.permissions",
+	ChangePasswordCode:       "user.password",
+	LoginUserCode:            "user.login",
+	LogoutUserCode:           "user.logout",
+	GetAccessTokensCode:      "personal_access_token.list",
+	CreateAccessTokenCode:    "personal_access_token.create",
+	DeleteAccessTokenCode:    "personal_access_token.delete",
+	LoginWithAccessTokenCode: "personal_access_token.login",
+	PollMessagesCode:         "message.poll",
+	SendMessagesCode:         "message.send",
+	FlushUnsavedBufferCode:   "message.flush_unsaved_buffer",
+	GetOffsetCode:            "consumer_offset.get",
+	StoreOffsetCode:          "consumer_offset.store",
+	DeleteOffsetCode:         "consumer_offset.delete",
+	GetStreamCode:            "stream.get",
+	GetStreamsCode:           "stream.list",
+	CreateStreamCode:         "stream.create",
+	DeleteStreamCode:         "stream.delete",
+	UpdateStreamCode:         "stream.update",
+	PurgeStreamCode:          "stream.purge",
+	GetTopicCode:             "topic.get",
+	GetTopicsCode:            "topic.list",
+	CreateTopicCode:          "topic.create",
+	DeleteTopicCode:          "topic.delete",
+	UpdateTopicCode:          "topic.update",
+	PurgeTopicCode:           "topic.purge",
+	CreatePartitionsCode:     "partition.create",
+	DeletePartitionsCode:     "partition.delete",
+	DeleteSegmentsCode:       "segment.delete",
+	GetGroupCode:             "consumer_group.get",
+	GetGroupsCode:            "consumer_group.list",
+	CreateGroupCode:          "consumer_group.create",
+	DeleteGroupCode:          "consumer_group.delete",
+	JoinGroupCode:            "consumer_group.join",
+	LeaveGroupCode:           "consumer_group.leave",
+}
+
+// String returns the name of the command as used by the server, e.g. "stream.purge".
+func (c CommandCode) String() string {
+	if name, ok := commandNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("command(%d)", int(c))
+}
+
 //    internal const int GET_PERSONAL_ACCESS_TOKENS_CODE = 41;
 //    internal const int CREATE_PERSONAL_ACCESS_TOKEN_CODE = 42;
 //    internal const int DELETE_PERSONAL_ACCESS_TOKEN_CODE = 43;
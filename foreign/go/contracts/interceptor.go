@@ -0,0 +1,67 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggcon
+
+import (
+	"context"
+	"time"
+)
+
+// CommandInvoker sends a command with its serialized request and returns the serialized response. The TCP client uses
+// the binary protocol payloads, the HTTP client the JSON bodies.
+type CommandInvoker func(ctx context.Context, command CommandCode, request []byte) ([]byte, error)
+
+// CommandInterceptor wraps every command sent by a client. It may change the request, short-circuit the command by
+// returning without calling next, or retry it by calling next several times.
+type CommandInterceptor func(ctx context.Context, command CommandCode, request []byte, next CommandInvoker) ([]byte, error)
+
+// ChainInterceptors runs the invoker through the interceptors, the first interceptor being the outermost one.
+func ChainInterceptors(invoker CommandInvoker, interceptors ...CommandInterceptor) CommandInvoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, command CommandCode, request []byte) ([]byte, error) {
+			return interceptor(ctx, command, request, next)
+		}
+	}
+	return invoker
+}
+
+// CommandCall describes a completed command.
+type CommandCall struct {
+	Command  CommandCode
+	Request  []byte
+	Response []byte
+	Err      error
+	Duration time.Duration
+}
+
+// ObserveCommands returns an interceptor reporting every completed command, e.g. for logging or metrics.
+func ObserveCommands(observe func(call CommandCall)) CommandInterceptor {
+	return func(ctx context.Context, command CommandCode, request []byte, next CommandInvoker) ([]byte, error) {
+		start := time.Now()
+		response, err := next(ctx, command, request)
+		observe(CommandCall{
+			Command:  command,
+			Request:  request,
+			Response: response,
+			Err:      err,
+			Duration: time.Since(start),
+		})
+		return response, err
+	}
+}
@@ -53,9 +53,9 @@ func MessageHeaderFromBytes(data []byte) (*MessageHeader, error) {
 	}
 	checksum := binary.LittleEndian.Uint64(data[0:8])
 	id := data[8:24]
-	timestamp := binary.LittleEndian.Uint64(data[24:32])
-	originTimestamp := binary.LittleEndian.Uint64(data[32:40])
-	offset := binary.LittleEndian.Uint64(data[40:48])
+	offset := binary.LittleEndian.Uint64(data[24:32])
+	timestamp := binary.LittleEndian.Uint64(data[32:40])
+	originTimestamp := binary.LittleEndian.Uint64(data[40:48])
 	userHeaderLength := binary.LittleEndian.Uint32(data[48:52])
 	payloadLength := binary.LittleEndian.Uint32(data[52:56])
 
@@ -71,16 +71,18 @@ func MessageHeaderFromBytes(data []byte) (*MessageHeader, error) {
 }
 
 func (mh *MessageHeader) ToBytes() []byte {
-	bytes := make([]byte, 0, MessageHeaderSize)
-
-	bytes = binary.LittleEndian.AppendUint64(bytes, mh.Checksum)
-	idBytes := mh.Id[:]
-	bytes = append(bytes, idBytes...)
-	bytes = binary.LittleEndian.AppendUint64(bytes, mh.Offset)
-	bytes = binary.LittleEndian.AppendUint64(bytes, mh.Timestamp)
-	bytes = binary.LittleEndian.AppendUint64(bytes, mh.OriginTimestamp)
-	bytes = binary.LittleEndian.AppendUint32(bytes, mh.UserHeaderLength)
-	bytes = binary.LittleEndian.AppendUint32(bytes, mh.PayloadLength)
-
+	bytes := make([]byte, MessageHeaderSize)
+	mh.PutBytes(bytes)
 	return bytes
 }
+
+// PutBytes writes the header into the first MessageHeaderSize bytes of the buffer, which must be large enough.
+func (mh *MessageHeader) PutBytes(bytes []byte) {
+	binary.LittleEndian.PutUint64(bytes[0:8], mh.Checksum)
+	copy(bytes[8:24], mh.Id[:])
+	binary.LittleEndian.PutUint64(bytes[24:32], mh.Offset)
+	binary.LittleEndian.PutUint64(bytes[32:40], mh.Timestamp)
+	binary.LittleEndian.PutUint64(bytes[40:48], mh.OriginTimestamp)
+	binary.LittleEndian.PutUint32(bytes[48:52], mh.UserHeaderLength)
+	binary.LittleEndian.PutUint32(bytes[52:56], mh.PayloadLength)
+}
@@ -0,0 +1,216 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggcon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"iter"
+
+	ierror "github.com/apache/iggy/foreign/go/errors"
+	"github.com/klauspost/compress/s2"
+)
+
+// MessageHeaderView reads the header fields directly from the MessageHeaderSize bytes of a polled message.
+type MessageHeaderView []byte
+
+func (h MessageHeaderView) Checksum() uint64 {
+	return binary.LittleEndian.Uint64(h[0:8])
+}
+
+func (h MessageHeaderView) Id() MessageID {
+	return MessageID(h[8:24])
+}
+
+func (h MessageHeaderView) Offset() uint64 {
+	return binary.LittleEndian.Uint64(h[24:32])
+}
+
+func (h MessageHeaderView) Timestamp() uint64 {
+	return binary.LittleEndian.Uint64(h[32:40])
+}
+
+func (h MessageHeaderView) OriginTimestamp() uint64 {
+	return binary.LittleEndian.Uint64(h[40:48])
+}
+
+func (h MessageHeaderView) UserHeaderLength() uint32 {
+	return binary.LittleEndian.Uint32(h[48:52])
+}
+
+func (h MessageHeaderView) PayloadLength() uint32 {
+	return binary.LittleEndian.Uint32(h[52:56])
+}
+
+// ToHeader copies the fields into a MessageHeader.
+func (h MessageHeaderView) ToHeader() MessageHeader {
+	return MessageHeader{
+		Checksum:         h.Checksum(),
+		Id:               h.Id(),
+		Offset:           h.Offset(),
+		Timestamp:        h.Timestamp(),
+		OriginTimestamp:  h.OriginTimestamp(),
+		UserHeaderLength: h.UserHeaderLength(),
+		PayloadLength:    h.PayloadLength(),
+	}
+}
+
+// IggyMessageView is a polled message read in place from the response buffer, its slices are only valid as long as
+// the buffer is not modified.
+type IggyMessageView struct {
+	buffer      []byte
+	compression IggyMessageCompression
+}
+
+func (m IggyMessageView) Header() MessageHeaderView {
+	return MessageHeaderView(m.buffer[:MessageHeaderSize])
+}
+
+// Payload returns the payload as sent over the wire, compressed if the client uses a message compression.
+func (m IggyMessageView) Payload() []byte {
+	end := MessageHeaderSize + int(m.Header().PayloadLength())
+	return m.buffer[MessageHeaderSize:end:end]
+}
+
+// UserHeaders returns the serialized user headers, or nil if the message has none.
+func (m IggyMessageView) UserHeaders() []byte {
+	if m.Header().UserHeaderLength() == 0 {
+		return nil
+	}
+	start := MessageHeaderSize + int(m.Header().PayloadLength())
+	return m.buffer[start:len(m.buffer):len(m.buffer)]
+}
+
+// UserHeadersMap deserializes the user headers, or returns nil if the message has none.
+func (m IggyMessageView) UserHeadersMap() (map[HeaderKey]HeaderValue, error) {
+	userHeaders := m.UserHeaders()
+	if userHeaders == nil {
+		return nil, nil
+	}
+	return DeserializeHeaders(userHeaders)
+}
+
+// Size returns the number of bytes taken by the message in the response.
+func (m IggyMessageView) Size() int {
+	return len(m.buffer)
+}
+
+// CalculateChecksum returns the CRC32 of the message as received, everything but the checksum field included.
+func (m IggyMessageView) CalculateChecksum() uint64 {
+	return uint64(crc32.ChecksumIEEE(m.buffer[8:]))
+}
+
+// ToMessage copies the message out of the response buffer, decompressing its payload if needed.
+func (m IggyMessageView) ToMessage() (IggyMessage, error) {
+	payload := m.Payload()
+	switch m.compression {
+	case MESSAGE_COMPRESSION_S2, MESSAGE_COMPRESSION_S2_BETTER, MESSAGE_COMPRESSION_S2_BEST:
+		decoded, err := s2.Decode(nil, payload)
+		if err != nil {
+			return IggyMessage{}, fmt.Errorf("iggy: failed to decode s2 payload: %w", err)
+		}
+		payload = decoded
+	default:
+		payload = append([]byte(nil), payload...)
+	}
+
+	var userHeaders []byte
+	if source := m.UserHeaders(); source != nil {
+		userHeaders = append([]byte(nil), source...)
+	}
+	return IggyMessage{
+		Header:      m.Header().ToHeader(),
+		Payload:     payload,
+		UserHeaders: userHeaders,
+	}, nil
+}
+
+// PolledMessageView is the result of a poll whose messages are read lazily from the response buffer instead of being
+// copied and decompressed up front.
+type PolledMessageView struct {
+	PartitionId   uint32
+	CurrentOffset uint64
+	MessageCount  uint32
+	messages      []byte
+	compression   IggyMessageCompression
+}
+
+// NewPolledMessageView wraps the serialized messages of a poll response, the messages are not validated until iterated.
+func NewPolledMessageView(partitionId uint32, currentOffset uint64, messageCount uint32, messages []byte, compression IggyMessageCompression) *PolledMessageView {
+	return &PolledMessageView{
+		PartitionId:   partitionId,
+		CurrentOffset: currentOffset,
+		MessageCount:  messageCount,
+		messages:      messages,
+		compression:   compression,
+	}
+}
+
+// All iterates over the messages in place, stopping at the first truncated message.
+func (p *PolledMessageView) All() iter.Seq[IggyMessageView] {
+	return func(yield func(IggyMessageView) bool) {
+		position := 0
+		for position+MessageHeaderSize < len(p.messages) {
+			header := MessageHeaderView(p.messages[position : position+MessageHeaderSize])
+			end := position + MessageHeaderSize + int(header.PayloadLength()) + int(header.UserHeaderLength())
+			if end > len(p.messages) {
+				return
+			}
+			if !yield(IggyMessageView{buffer: p.messages[position:end:end], compression: p.compression}) {
+				return
+			}
+			position = end
+		}
+	}
+}
+
+// VerifyChecksums checks the checksum of every message, and returns an *ierror.MessageChecksumError
+// for the first one whose content was altered.
+func (p *PolledMessageView) VerifyChecksums() error {
+	for view := range p.All() {
+		header := view.Header()
+		if calculated := view.CalculateChecksum(); calculated != header.Checksum() {
+			return &ierror.MessageChecksumError{
+				PartitionId: p.PartitionId,
+				Offset:      header.Offset(),
+				Checksum:    header.Checksum(),
+				Calculated:  calculated,
+			}
+		}
+	}
+	return nil
+}
+
+// ToPolledMessage copies every message out of the response buffer.
+func (p *PolledMessageView) ToPolledMessage() (*PolledMessage, error) {
+	messages := make([]IggyMessage, 0, p.MessageCount)
+	for view := range p.All() {
+		message, err := view.ToMessage()
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return &PolledMessage{
+		PartitionId:   p.PartitionId,
+		CurrentOffset: p.CurrentOffset,
+		MessageCount:  p.MessageCount,
+		Messages:      messages,
+	}, nil
+}
@@ -18,6 +18,8 @@
 package iggcon
 
 import (
+	"hash/crc32"
+
 	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
@@ -69,6 +71,13 @@ type SendMessagesRequest struct {
 	Messages     []IggyMessage `json:"messages"`
 }
 
+type FlushUnsavedBufferRequest struct {
+	StreamId    Identifier `json:"streamId"`
+	TopicId     Identifier `json:"topicId"`
+	PartitionId uint32     `json:"partitionId"`
+	Fsync       bool       `json:"fsync"`
+}
+
 type ReceivedMessage struct {
 	Message       IggyMessage
 	CurrentOffset uint64
@@ -81,6 +90,17 @@ type IggyMessage struct {
 	UserHeaders []byte
 }
 
+// CalculateChecksum returns the CRC32 the server computes for the message, over the serialized header without its
+// checksum field, the payload and the user headers. The payload is taken as is, so it must be the compressed one
+// when the client compresses the messages.
+func (m *IggyMessage) CalculateChecksum() uint64 {
+	var header [MessageHeaderSize]byte
+	m.Header.PutBytes(header[:])
+	checksum := crc32.ChecksumIEEE(header[8:])
+	checksum = crc32.Update(checksum, crc32.IEEETable, m.Payload)
+	return uint64(crc32.Update(checksum, crc32.IEEETable, m.UserHeaders))
+}
+
 type IggyMessageOpt func(message *IggyMessage)
 
 // NewIggyMessage Creates a new message with customizable parameters.
@@ -32,6 +32,13 @@ type GetConsumerOffsetRequest struct {
 	PartitionId *uint32    `json:"partitionId"`
 }
 
+type DeleteConsumerOffsetRequest struct {
+	StreamId    Identifier `json:"streamId"`
+	TopicId     Identifier `json:"topicId"`
+	Consumer    Consumer   `json:"consumer"`
+	PartitionId *uint32    `json:"partitionId"`
+}
+
 type ConsumerOffsetInfo struct {
 	PartitionId   int    `json:"partitionId"`
 	CurrentOffset uint64 `json:"currentOffset"`
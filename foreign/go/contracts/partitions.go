@@ -45,6 +45,13 @@ type DeletePartitionsRequest struct {
 	PartitionsCount uint32     `json:"partitionsCount"`
 }
 
+type DeleteSegmentsRequest struct {
+	StreamId      Identifier `json:"streamId"`
+	TopicId       Identifier `json:"topicId"`
+	PartitionId   uint32     `json:"partitionId"`
+	SegmentsCount uint32     `json:"segmentsCount"`
+}
+
 type PartitioningKind int
 
 const (
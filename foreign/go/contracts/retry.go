@@ -0,0 +1,141 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggcon
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+// RetryPolicy configures how the commands failing with a retryable error are sent again.
+//
+// A command rejected by the server with a retryable error code was not applied, so it is retried whatever the command.
+// A command failing with a connection error may have reached the server already, so it is only retried when it is
+// idempotent. With the TCP reconnect enabled, the connection is restored before the command is retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per command, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled after each attempt up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter is the fraction of each delay which is randomized, between 0 and 1.
+	Jitter float64
+	// Idempotent reports whether the command can be sent twice, it defaults to IsIdempotentCommand.
+	Idempotent func(command CommandCode) bool
+	// Retryable reports whether the error is transient, it defaults to ierror.IsRetryable.
+	Retryable func(err error) bool
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond * 100,
+		MaxBackoff:     time.Second * 5,
+		Jitter:         0.2,
+	}
+}
+
+// idempotentCommands lists the commands whose repetition leaves the server in the same state.
+// The polls are not included as they may store the consumer offset, nor the creations and deletions which fail
+// when repeated.
+var idempotentCommands = map[CommandCode]bool{
+	PingCode:                 true,
+	GetStatsCode:             true,
+	GetSnapshotFileCode:      true,
+	GetMeCode:                true,
+	GetClientCode:            true,
+	GetClientsCode:           true,
+	GetUserCode:              true,
+	GetUsersCode:             true,
+	UpdateUserCode:           true,
+	UpdatePermissionsCode:    true,
+	LoginUserCode:            true,
+	LoginWithAccessTokenCode: true,
+	GetAccessTokensCode:      true,
+	FlushUnsavedBufferCode:   true,
+	GetOffsetCode:            true,
+	StoreOffsetCode:          true,
+	GetStreamCode:            true,
+	GetStreamsCode:           true,
+	UpdateStreamCode:         true,
+	PurgeStreamCode:          true,
+	GetTopicCode:             true,
+	GetTopicsCode:            true,
+	UpdateTopicCode:          true,
+	PurgeTopicCode:           true,
+	GetGroupCode:             true,
+	GetGroupsCode:            true,
+}
+
+// IsIdempotentCommand reports whether the command can be sent again when it is unknown if the server received it.
+func IsIdempotentCommand(command CommandCode) bool {
+	return idempotentCommands[command]
+}
+
+// RetryCommands returns an interceptor retrying the commands according to the policy.
+func RetryCommands(policy RetryPolicy) CommandInterceptor {
+	if policy.Idempotent == nil {
+		policy.Idempotent = IsIdempotentCommand
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = ierror.IsRetryable
+	}
+
+	return func(ctx context.Context, command CommandCode, request []byte, next CommandInvoker) ([]byte, error) {
+		backoff := policy.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			response, err := next(ctx, command, request)
+			if err == nil || attempt >= policy.MaxAttempts || !policy.retries(command, err) {
+				return response, err
+			}
+
+			timer := time.NewTimer(policy.jitter(backoff))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, err
+			case <-timer.C:
+			}
+			backoff *= 2
+			if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+	}
+}
+
+func (p RetryPolicy) retries(command CommandCode, err error) bool {
+	if !p.Retryable(err) {
+		return false
+	}
+	// An error code comes from the server, which did not apply the command.
+	var iggyErr *ierror.IggyError
+	return errors.As(err, &iggyErr) || p.Idempotent(command)
+}
+
+func (p RetryPolicy) jitter(backoff time.Duration) time.Duration {
+	if p.Jitter <= 0 || backoff <= 0 {
+		return backoff
+	}
+	spread := time.Duration(float64(backoff) * min(p.Jitter, 1))
+	return backoff - spread + rand.N(2*spread+1)
+}
@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggcon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+// SemanticVersion is a version in the numeric format used by the server, major * 1000000 + minor * 1000 + patch.
+type SemanticVersion uint32
+
+func NewSemanticVersion(major, minor, patch uint32) SemanticVersion {
+	return SemanticVersion(major*1000000 + minor*1000 + patch)
+}
+
+func (v SemanticVersion) Major() uint32 {
+	return uint32(v) / 1000000
+}
+
+func (v SemanticVersion) Minor() uint32 {
+	return uint32(v) / 1000 % 1000
+}
+
+func (v SemanticVersion) Patch() uint32 {
+	return uint32(v) % 1000
+}
+
+func (v SemanticVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+}
+
+// ServerVersion identifies the server a client talks to, its zero value means that the version is unknown.
+type ServerVersion struct {
+	Version string
+	Semver  SemanticVersion
+}
+
+// ServerVersionOf returns the version reported in the server stats, which is unknown for the servers not reporting it.
+func ServerVersionOf(stats *Stats) ServerVersion {
+	return ServerVersion{
+		Version: stats.IggyServerVersion,
+		Semver:  stats.IggyServerSemver,
+	}
+}
+
+func (v ServerVersion) Known() bool {
+	return v.Semver != 0
+}
+
+func (v ServerVersion) String() string {
+	switch {
+	case v.Version != "":
+		return v.Version
+	case v.Known():
+		return v.Semver.String()
+	default:
+		return "unknown"
+	}
+}
+
+// capabilityCommands are the commands added after the first server releases, which the servers predating them
+// reject as invalid commands.
+var capabilityCommands = map[CommandCode]bool{
+	PurgeStreamCode:        true,
+	PurgeTopicCode:         true,
+	DeleteSegmentsCode:     true,
+	FlushUnsavedBufferCode: true,
+	GetSnapshotFileCode:    true,
+}
+
+// UnsupportedCommandError is returned for a command which the server rejected as invalid while it is one of the
+// commands added after the first server releases. It wraps the error of the server.
+type UnsupportedCommandError struct {
+	Command CommandCode
+	Server  ServerVersion
+	Err     error
+}
+
+func (e *UnsupportedCommandError) Error() string {
+	if !e.Server.Known() {
+		return fmt.Sprintf("command %v is unsupported by the server of unknown version: %v", e.Command, e.Err)
+	}
+	return fmt.Sprintf("command %v is unsupported by server v%v: %v", e.Command, e.Server, e.Err)
+}
+
+func (e *UnsupportedCommandError) Unwrap() error {
+	return e.Err
+}
+
+// RequireCapabilities returns an interceptor reporting the invalid_command error of the purge, segment deletion,
+// flush and snapshot commands as an UnsupportedCommandError naming the server version.
+// The commands are always sent, as their minimum server versions are not recorded.
+func RequireCapabilities(version func() ServerVersion) CommandInterceptor {
+	return func(ctx context.Context, command CommandCode, request []byte, next CommandInvoker) ([]byte, error) {
+		response, err := next(ctx, command, request)
+		if !capabilityCommands[command] {
+			return response, err
+		}
+		var iggyErr *ierror.IggyError
+		if errors.As(err, &iggyErr) && iggyErr.Code == ierror.InvalidCommand.Code {
+			return nil, &UnsupportedCommandError{Command: command, Server: version(), Err: err}
+		}
+		return response, err
+	}
+}
@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggcon
+
+import "io"
+
+// SnapshotType is a kind of diagnostic data included in a system snapshot.
+type SnapshotType uint8
+
+const (
+	// SnapshotFilesystemOverview is an overview of the server filesystem.
+	SnapshotFilesystemOverview SnapshotType = 1
+	// SnapshotProcessList is the list of the processes running on the server host.
+	SnapshotProcessList SnapshotType = 2
+	// SnapshotResourceUsage is the resource usage of the server host.
+	SnapshotResourceUsage SnapshotType = 3
+	// SnapshotTest is meant for the development of the server.
+	SnapshotTest SnapshotType = 4
+	// SnapshotServerLogs are the logs of the server.
+	SnapshotServerLogs SnapshotType = 5
+	// SnapshotServerConfig is the configuration of the server.
+	SnapshotServerConfig SnapshotType = 6
+	// SnapshotAll includes every type, it cannot be combined with the other types.
+	SnapshotAll SnapshotType = 100
+)
+
+// SnapshotCompression is the compression of the files in the snapshot archive.
+type SnapshotCompression uint8
+
+const (
+	SnapshotStored   SnapshotCompression = 1
+	SnapshotDeflated SnapshotCompression = 2
+	SnapshotBzip2    SnapshotCompression = 3
+	SnapshotZstd     SnapshotCompression = 4
+	SnapshotLzma     SnapshotCompression = 5
+	SnapshotXz       SnapshotCompression = 6
+)
+
+type GetSnapshotRequest struct {
+	Compression   SnapshotCompression `json:"compression"`
+	SnapshotTypes []SnapshotType      `json:"snapshotTypes"`
+}
+
+// Snapshot is a zip archive of the diagnostic data of the server.
+// The whole archive is held in memory: the clients read the response in full before returning it,
+// so a snapshot including large server logs takes as much memory as the archive size.
+type Snapshot []byte
+
+// WriteTo writes the archive to w, for example a file to be attached to a support request.
+// It writes the archive already held in memory, it does not stream it from the server.
+func (s Snapshot) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(s)
+	return int64(n), err
+}
@@ -17,6 +17,12 @@
 
 package iggcon
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 type Stats struct {
 	ProcessId           int     `json:"process_id"`
 	CpuUsage            float32 `json:"cpu_usage"`
@@ -40,4 +46,49 @@ type Stats struct {
 	OsName              string  `json:"os_name"`
 	OsVersion           string  `json:"os_version"`
 	KernelVersion       string  `json:"kernel_version"`
+	IggyServerVersion   string  `json:"iggy_server_version"`
+	// IggyServerSemver is the server version in the numeric format, 0 if the server does not report it.
+	IggyServerSemver SemanticVersion `json:"iggy_server_semver"`
+	// CacheMetrics are the metrics of the partitions message caches, empty if the server does not report them.
+	CacheMetrics map[CacheMetricsKey]CacheMetrics `json:"cache_metrics"`
+}
+
+// CacheMetricsKey identifies the partition of the cache metrics.
+type CacheMetricsKey struct {
+	StreamId    uint32
+	TopicId     uint32
+	PartitionId uint32
+}
+
+// String formats the key as "stream-topic-partition", as the server does in its JSON stats.
+func (k CacheMetricsKey) String() string {
+	return fmt.Sprintf("%d-%d-%d", k.StreamId, k.TopicId, k.PartitionId)
+}
+
+func (k CacheMetricsKey) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *CacheMetricsKey) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), "-")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid cache metrics key: %q", text)
+	}
+	var ids [3]uint32
+	for i, part := range parts {
+		id, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid cache metrics key: %q", text)
+		}
+		ids[i] = uint32(id)
+	}
+	*k = CacheMetricsKey{StreamId: ids[0], TopicId: ids[1], PartitionId: ids[2]}
+	return nil
+}
+
+// CacheMetrics are the hits and misses of the message cache of a partition.
+type CacheMetrics struct {
+	Hits     uint64  `json:"hits"`
+	Misses   uint64  `json:"misses"`
+	HitRatio float32 `json:"hit_ratio"`
 }
@@ -17,40 +17,161 @@
 
 package iggcon
 
-import "time"
+import (
+	"math"
+	"time"
+
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+// MaxPartitionsCount is the largest number of partitions a topic can be created with.
+const MaxPartitionsCount = 1000
+
+// MaxTopicNameLength is the longest name, in bytes, a topic can have.
+const MaxTopicNameLength = 255
+
+// CompressionAlgorithm is the algorithm the server compresses the messages of a topic with.
+// The zero value stands for CompressionNone.
+type CompressionAlgorithm uint8
+
+const (
+	CompressionNone CompressionAlgorithm = 1
+	CompressionGzip CompressionAlgorithm = 2
+)
+
+// Code returns the code of the algorithm in the binary protocol.
+func (c CompressionAlgorithm) Code() uint8 {
+	if c == 0 {
+		return uint8(CompressionNone)
+	}
+	return uint8(c)
+}
+
+// String returns the name of the algorithm used by the HTTP API.
+func (c CompressionAlgorithm) String() string {
+	if c == CompressionGzip {
+		return "gzip"
+	}
+	return "none"
+}
+
+// IggyExpiry is the time, in microseconds, after which the messages of a topic expire.
+type IggyExpiry uint64
+
+const (
+	// ExpiryServerDefault leaves the expiry to the configuration of the server.
+	ExpiryServerDefault IggyExpiry = 0
+	// ExpiryNever keeps the messages until they are deleted or the topic runs out of space.
+	ExpiryNever IggyExpiry = math.MaxUint64
+)
+
+// ExpireAfter makes the messages expire after the given duration, rounded up to a whole microsecond.
+// Durations that are not positive expire the messages after a microsecond
+// instead of turning into ExpiryServerDefault.
+func ExpireAfter(d time.Duration) IggyExpiry {
+	if d < time.Microsecond {
+		return 1
+	}
+	return IggyExpiry((d + time.Microsecond - 1) / time.Microsecond)
+}
+
+// Duration returns the expiry as a duration, or false for ExpiryServerDefault and ExpiryNever.
+// The expiries too long for a duration are capped to the longest one.
+func (e IggyExpiry) Duration() (time.Duration, bool) {
+	if e == ExpiryServerDefault || e == ExpiryNever {
+		return 0, false
+	}
+	if uint64(e) > uint64(math.MaxInt64/time.Microsecond) {
+		return math.MaxInt64, true
+	}
+	return time.Duration(e) * time.Microsecond, true
+}
+
+// MaxTopicSize is the size in bytes a topic can grow to before its oldest segments are deleted.
+type MaxTopicSize uint64
+
+const (
+	// MaxTopicSizeServerDefault leaves the limit to the configuration of the server.
+	MaxTopicSizeServerDefault MaxTopicSize = 0
+	// MaxTopicSizeUnlimited lets the topic grow without limit.
+	MaxTopicSizeUnlimited MaxTopicSize = math.MaxUint64
+)
+
+// TopicConfig holds the settings of a topic. Its zero value creates an uncompressed topic
+// with the expiry, maximum size and replication factor of the server configuration.
+type TopicConfig struct {
+	CompressionAlgorithm CompressionAlgorithm `json:"compressionAlgorithm"`
+	MessageExpiry        IggyExpiry           `json:"messageExpiry"`
+	MaxTopicSize         MaxTopicSize         `json:"maxTopicSize"`
+	// ReplicationFactor of zero leaves the replication to the server.
+	ReplicationFactor uint8 `json:"replicationFactor"`
+}
+
+// Validate checks the settings before they are sent to the server.
+// The replication factor needs no check: the server only rejects zero, which stands for its default here.
+func (c TopicConfig) Validate() error {
+	if c.CompressionAlgorithm > CompressionGzip {
+		return ierror.CustomError("invalid_compression_algorithm")
+	}
+	return nil
+}
+
+// ValidateCreateTopic checks the name, the partitions count and the settings of a topic to be created,
+// with the same limits as the server.
+func ValidateCreateTopic(name string, partitionsCount uint32, config TopicConfig) error {
+	if err := validateTopicName(name); err != nil {
+		return err
+	}
+	if MaxPartitionsCount < partitionsCount {
+		return ierror.TooManyPartitions
+	}
+	return config.Validate()
+}
+
+// ValidateUpdateTopic checks the name and the settings of a topic to be updated, with the same limits as the server.
+func ValidateUpdateTopic(name string, config TopicConfig) error {
+	if err := validateTopicName(name); err != nil {
+		return err
+	}
+	return config.Validate()
+}
+
+func validateTopicName(name string) error {
+	if name == "" {
+		return ierror.InvalidTopicName
+	}
+	if MaxTopicNameLength < len(name) {
+		return ierror.TextTooLong("topic_name")
+	}
+	return nil
+}
 
 type CreateTopicRequest struct {
-	StreamId             Identifier    `json:"streamId"`
-	TopicId              int           `json:"topicId"`
-	PartitionsCount      int           `json:"partitionsCount"`
-	CompressionAlgorithm uint8         `json:"compressionAlgorithm"`
-	MessageExpiry        time.Duration `json:"messageExpiry"`
-	MaxTopicSize         uint64        `json:"maxTopicSize"`
-	ReplicationFactor    uint8         `json:"replicationFactor"`
-	Name                 string        `json:"name"`
+	TopicConfig
+	StreamId        Identifier `json:"streamId"`
+	TopicId         int        `json:"topicId"`
+	PartitionsCount uint32     `json:"partitionsCount"`
+	Name            string     `json:"name"`
 }
 
 type UpdateTopicRequest struct {
-	StreamId             Identifier    `json:"streamId"`
-	TopicId              Identifier    `json:"topicId"`
-	CompressionAlgorithm uint8         `json:"compressionAlgorithm"`
-	MessageExpiry        time.Duration `json:"messageExpiry"`
-	MaxTopicSize         uint64        `json:"maxTopicSize"`
-	ReplicationFactor    uint8         `json:"replicationFactor"`
-	Name                 string        `json:"name"`
+	TopicConfig
+	StreamId Identifier `json:"streamId"`
+	TopicId  Identifier `json:"topicId"`
+	Name     string     `json:"name"`
 }
 
 type Topic struct {
-	Id                   int           `json:"id"`
-	CreatedAt            int           `json:"createdAt"`
-	Name                 string        `json:"name"`
-	SizeBytes            uint64        `json:"sizeBytes"`
-	MessageExpiry        time.Duration `json:"messageExpiry"`
-	CompressionAlgorithm uint8         `json:"compressionAlgorithm"`
-	MaxTopicSize         uint64        `json:"maxTopicSize"`
-	ReplicationFactor    uint8         `json:"replicationFactor"`
-	MessagesCount        uint64        `json:"messagesCount"`
-	PartitionsCount      int           `json:"partitionsCount"`
+	Id                   int                  `json:"id"`
+	CreatedAt            int                  `json:"createdAt"`
+	Name                 string               `json:"name"`
+	SizeBytes            uint64               `json:"sizeBytes"`
+	MessageExpiry        IggyExpiry           `json:"messageExpiry"`
+	CompressionAlgorithm CompressionAlgorithm `json:"compressionAlgorithm"`
+	MaxTopicSize         MaxTopicSize         `json:"maxTopicSize"`
+	ReplicationFactor    uint8                `json:"replicationFactor"`
+	MessagesCount        uint64               `json:"messagesCount"`
+	PartitionsCount      int                  `json:"partitionsCount"`
 }
 
 type TopicDetails struct {
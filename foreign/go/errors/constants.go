@@ -34,6 +34,14 @@ var (
 		Code:    2010,
 		Message: "topic_id_not_found",
 	}
+	InvalidTopicName = &IggyError{
+		Code:    2014,
+		Message: "invalid_topic_name",
+	}
+	TooManyPartitions = &IggyError{
+		Code:    2015,
+		Message: "too_many_partitions",
+	}
 	InvalidMessagesCount = &IggyError{
 		Code:    4009,
 		Message: "invalid_messages_count",
@@ -50,8 +58,56 @@ var (
 		Code:    4017,
 		Message: "too_big_headers_payload",
 	}
+	InvalidMessageChecksum = &IggyError{
+		Code:    4027,
+		Message: "invalid_message_checksum",
+	}
 	ConsumerGroupIdNotFound = &IggyError{
 		Code:    5000,
 		Message: "consumer_group_not_found",
 	}
+	InvalidCommand = &IggyError{
+		Code:    3,
+		Message: "invalid_command",
+	}
+	FeatureUnavailable = &IggyError{
+		Code:    5,
+		Message: "feature_unavailable",
+	}
+	NotConnected = &IggyError{
+		Code:    61,
+		Message: "not_connected",
+	}
+	ClientShutdown = &IggyError{
+		Code:    63,
+		Message: "client_shutdown",
+	}
+	Unauthenticated = &IggyError{
+		Code:    40,
+		Message: "unauthenticated",
+	}
+	Unauthorized = &IggyError{
+		Code:    41,
+		Message: "unauthorized",
+	}
+	JwtMissing = &IggyError{
+		Code:    75,
+		Message: "jwt_missing",
+	}
+	AccessTokenMissing = &IggyError{
+		Code:    77,
+		Message: "access_token_missing",
+	}
+	InvalidJsonResponse = &IggyError{
+		Code:    302,
+		Message: "invalid_json_response",
+	}
+	InvalidBytesResponse = &IggyError{
+		Code:    303,
+		Message: "invalid_bytes_response",
+	}
+	CannotParseUrl = &IggyError{
+		Code:    306,
+		Message: "cannot_parse_url",
+	}
 )
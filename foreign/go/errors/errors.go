@@ -42,6 +42,33 @@ func TextTooLong(message string) error {
 	}
 }
 
+// MessageChecksumError reports a polled message whose content does not match its checksum,
+// it matches InvalidMessageChecksum with errors.Is.
+type MessageChecksumError struct {
+	PartitionId uint32
+	Offset      uint64
+	// Checksum is the checksum sent by the server.
+	Checksum uint64
+	// Calculated is the checksum of the received content.
+	Calculated uint64
+}
+
+func (e *MessageChecksumError) Error() string {
+	return fmt.Sprintf("%v: '%v' (partition: %d, offset: %d, checksum: %d, calculated: %d)",
+		InvalidMessageChecksum.Code, InvalidMessageChecksum.Message, e.PartitionId, e.Offset, e.Checksum, e.Calculated)
+}
+
+func (e *MessageChecksumError) Is(target error) bool {
+	return target == InvalidMessageChecksum
+}
+
+func HttpResponseError(statusCode int, body string) error {
+	return &IggyError{
+		Code:    300,
+		Message: fmt.Sprintf("http_response_error (status: %d, body: %s)", statusCode, body),
+	}
+}
+
 func MapFromCode(code int) error {
 	return &IggyError{
 		Code:    code,
@@ -63,6 +90,10 @@ func TranslateErrorCode(code int) string {
 		return "feature_unavailable"
 	case 6:
 		return "invalid_identifier"
+	case 8:
+		return "disconnected"
+	case 9:
+		return "cannot_establish_connection"
 	case 10:
 		return "cannot_create_base_directory"
 	case 20:
@@ -77,6 +108,8 @@ func TranslateErrorCode(code int) string {
 		return "cannot_serialize_resource"
 	case 25:
 		return "cannot_deserialize_resource"
+	case 31:
+		return "tcp_error"
 	case 40:
 		return "unauthenticated"
 	case 41:
@@ -88,15 +121,23 @@ func TranslateErrorCode(code int) string {
 	case 44:
 		return "invalid_password"
 	case 51:
-		return "not_connected"
+		return "personal_access_token_already_exists"
 	case 52:
 		return "request_error"
 	case 60:
 		return "invalid_encryption_key"
 	case 61:
-		return "cannot_encrypt_data"
+		return "not_connected"
 	case 62:
 		return "cannot_decrypt_data"
+	case 63:
+		return "client_shutdown"
+	case 75:
+		return "jwt_missing"
+	case 77:
+		return "access_token_missing"
+	case 78:
+		return "invalid_access_token"
 	case 100:
 		return "client_not_found"
 	case 101:
@@ -116,17 +157,17 @@ func TranslateErrorCode(code int) string {
 	case 300:
 		return "http_response_error"
 	case 301:
-		return "request_middleware_error"
+		return "invalid_http_request"
 	case 302:
-		return "cannot_create_endpoint"
+		return "invalid_json_response"
 	case 303:
-		return "cannot_parse_url"
+		return "invalid_bytes_response"
 	case 304:
-		return "invalid_response"
-	case 305:
 		return "empty_response"
+	case 305:
+		return "cannot_create_endpoint"
 	case 306:
-		return "cannot_parse_address"
+		return "cannot_parse_url"
 	case 307:
 		return "read_error"
 	case 308:
@@ -281,6 +322,10 @@ func TranslateErrorCode(code int) string {
 		return "non_zero_timestamp"
 	case 4036:
 		return "invalid_messages_size"
+	case 4052:
+		return "background_send_timeout"
+	case 4053:
+		return "background_send_buffer_full"
 	case 4100:
 		return "invalid_offset"
 	case 4101:
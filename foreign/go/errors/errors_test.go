@@ -18,6 +18,10 @@
 package ierror
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net"
 	"testing"
 )
 
@@ -34,3 +38,53 @@ func TestIggyError_Error(t *testing.T) {
 		t.Errorf("Error() method mismatch, expected: %s, got: %s", expectedErrorString, actualErrorString)
 	}
 }
+
+func TestTranslateErrorCode(t *testing.T) {
+	tests := []struct {
+		code     int
+		expected string
+	}{
+		{code: 51, expected: "personal_access_token_already_exists"},
+		{code: 61, expected: "not_connected"},
+		{code: 300, expected: "http_response_error"},
+		{code: 301, expected: "invalid_http_request"},
+		{code: 302, expected: "invalid_json_response"},
+		{code: 303, expected: "invalid_bytes_response"},
+		{code: 304, expected: "empty_response"},
+		{code: 305, expected: "cannot_create_endpoint"},
+		{code: 306, expected: "cannot_parse_url"},
+	}
+	for _, tt := range tests {
+		if actual := TranslateErrorCode(tt.code); actual != tt.expected {
+			t.Errorf("TranslateErrorCode(%d): expected %s, got %s", tt.code, tt.expected, actual)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: FeatureUnavailable, expected: false},
+		{err: MapFromCode(51), expected: false},
+		{err: NotConnected, expected: true},
+		{err: MapFromCode(206), expected: true},
+		{err: MapFromCode(304), expected: true},
+		{err: MapFromCode(4052), expected: true},
+		{err: MapFromCode(4053), expected: true},
+		{err: MapFromCode(307), expected: false},
+		{err: MapFromCode(308), expected: false},
+		{err: Unauthenticated, expected: false},
+		{err: ClientShutdown, expected: false},
+		{err: fmt.Errorf("read: %w", io.EOF), expected: true},
+		{err: &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}, expected: true},
+		{err: context.DeadlineExceeded, expected: false},
+	}
+	for _, tt := range tests {
+		if actual := IsRetryable(tt.err); actual != tt.expected {
+			t.Errorf("IsRetryable(%v): expected %v, got %v", tt.err, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ierror
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+)
+
+// retryableCodes lists the codes of the server error catalog for transient failures, after which the same request
+// may succeed. The catalog has no server busy code, the closest being the timeout and the full buffer of the
+// background sending.
+var retryableCodes = map[int]bool{
+	8:    true, // disconnected
+	9:    true, // cannot_establish_connection
+	31:   true, // tcp_error
+	61:   true, // not_connected
+	206:  true, // connection_closed
+	304:  true, // empty_response
+	4052: true, // background_send_timeout
+	4053: true, // background_send_buffer_full
+}
+
+// IsRetryable reports whether the error is a transient failure, i.e. a connection failure or a server error code
+// which the same request may not get again. Context errors and the errors of a closed client are not retryable.
+// Neither is feature_unavailable: the Rust SDK returns it for a command its transport does not implement,
+// e.g. joining a consumer group over HTTP, which fails the same way on every attempt.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var iggyErr *IggyError
+	if errors.As(err, &iggyErr) {
+		return retryableCodes[iggyErr.Code]
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
@@ -0,0 +1,67 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+	"net/url"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func (c *IggyHttpClient) CreatePersonalAccessToken(name string, expiry uint32) (*RawPersonalAccessToken, error) {
+	return c.CreatePersonalAccessTokenCtx(c.ctx, name, expiry)
+}
+
+func (c *IggyHttpClient) CreatePersonalAccessTokenCtx(ctx context.Context, name string, expiry uint32) (*RawPersonalAccessToken, error) {
+	var response RawPersonalAccessToken
+	err := c.post(ctx, CreateAccessTokenCode, "/personal-access-tokens", createPersonalAccessTokenRequest{
+		Name:   name,
+		Expiry: uint64(expiry),
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *IggyHttpClient) DeletePersonalAccessToken(name string) error {
+	return c.DeletePersonalAccessTokenCtx(c.ctx, name)
+}
+
+func (c *IggyHttpClient) DeletePersonalAccessTokenCtx(ctx context.Context, name string) error {
+	return c.delete(ctx, DeleteAccessTokenCode, pathOf("personal-access-tokens", url.PathEscape(name)), nil)
+}
+
+func (c *IggyHttpClient) GetPersonalAccessTokens() ([]PersonalAccessTokenInfo, error) {
+	return c.GetPersonalAccessTokensCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) GetPersonalAccessTokensCtx(ctx context.Context) ([]PersonalAccessTokenInfo, error) {
+	var response []personalAccessTokenInfoResponse
+	if err := c.get(ctx, GetAccessTokensCode, "/personal-access-tokens", nil, &response); err != nil {
+		return nil, err
+	}
+
+	tokens := make([]PersonalAccessTokenInfo, 0, len(response))
+	for _, token := range response {
+		tokens = append(tokens, token.toContract())
+	}
+	return tokens, nil
+}
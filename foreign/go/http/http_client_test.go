@@ -0,0 +1,609 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func newTestClient(t *testing.T, handler http.Handler, options ...Option) *IggyHttpClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewIggyHttpClient(append([]Option{WithApiUrl(server.URL)}, options...)...)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	return client
+}
+
+func writeJson(t *testing.T, w http.ResponseWriter, status int, body any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		t.Errorf("failed to write the response: %v", err)
+	}
+}
+
+func identityResponse(token string, expiry time.Time) map[string]any {
+	return map[string]any{
+		"user_id": 1,
+		"access_token": map[string]any{
+			"token":  token,
+			"expiry": expiry.Unix(),
+		},
+	}
+}
+
+func TestNewIggyHttpClient_InvalidApiUrl(t *testing.T) {
+	_, err := NewIggyHttpClient(WithApiUrl("127.0.0.1:3000"))
+	if !errors.Is(err, ierror.CannotParseUrl) {
+		t.Fatalf("expected %v, got %v", ierror.CannotParseUrl, err)
+	}
+}
+
+func TestPingCtx_RespectsDeadline(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ping", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	client := newTestClient(t, mux)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.PingCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+}
+
+func TestClose_LogsOutAndRejectsLaterCalls(t *testing.T) {
+	var loggedOut atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token-1", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("DELETE /users/logout", func(w http.ResponseWriter, r *http.Request) {
+		loggedOut.Store(r.Header.Get("Authorization") == "Bearer token-1")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newTestClient(t, mux, WithLogoutOnClose())
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("failed to close the client: %v", err)
+	}
+	if !loggedOut.Load() {
+		t.Fatal("expected the user to be logged out when closing")
+	}
+	if err := client.Ping(); !errors.Is(err, ierror.ClientShutdown) {
+		t.Fatalf("expected %v after closing, got %v", ierror.ClientShutdown, err)
+	}
+}
+
+func TestClose_BoundsLogout(t *testing.T) {
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("DELETE /users/logout", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	client := newTestClient(t, mux, WithLogoutOnClose())
+	t.Cleanup(func() { close(release) })
+	client.logoutTimeout = 50 * time.Millisecond
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- client.Close()
+	}()
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the logout to time out, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the logout of an unresponsive server blocked Close")
+	}
+	if err := client.Ping(); !errors.Is(err, ierror.ClientShutdown) {
+		t.Fatalf("expected %v after closing, got %v", ierror.ClientShutdown, err)
+	}
+}
+
+func TestClose_ConcurrentCallsLogOutOnce(t *testing.T) {
+	var logouts atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("DELETE /users/logout", func(w http.ResponseWriter, r *http.Request) {
+		logouts.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newTestClient(t, mux, WithLogoutOnClose())
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	const calls = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, calls)
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.Is(err, ierror.ClientShutdown) {
+			t.Fatalf("expected %v for the other calls, got %v", ierror.ClientShutdown, err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("expected a single call to close the client, got %d", succeeded)
+	}
+	if n := logouts.Load(); n != 1 {
+		t.Fatalf("expected a single logout, got %d", n)
+	}
+}
+
+func TestInterceptors_SeeJsonBodies(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	var calls []CommandCall
+	client := newTestClient(t, mux, WithInterceptors(ObserveCommands(func(call CommandCall) { calls = append(calls, call) })))
+
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	// The login is followed by the detection of the server version.
+	if len(calls) != 2 || calls[0].Command != LoginUserCode || calls[0].Err != nil || calls[1].Command != GetStatsCode {
+		t.Fatalf("unexpected observed calls: %+v", calls)
+	}
+	if !strings.Contains(string(calls[0].Request), `"password":"secret"`) || !strings.Contains(string(calls[0].Response), `"token":"token"`) {
+		t.Errorf("expected the observed call to hold the JSON bodies, got %s and %s", calls[0].Request, calls[0].Response)
+	}
+}
+
+func TestLoginUser_SendsAccessTokenWithRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode the login request: %v", err)
+		}
+		if request["username"] != "iggy" || request["password"] != "secret" {
+			t.Errorf("unexpected credentials: %v", request)
+		}
+		writeJson(t, w, http.StatusOK, identityResponse("token-1", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("GET /streams", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			writeJson(t, w, http.StatusUnauthorized, map[string]any{"id": 40, "code": "unauthenticated"})
+			return
+		}
+		writeJson(t, w, http.StatusOK, []map[string]any{{
+			"id":             1,
+			"created_at":     1700000000000000,
+			"name":           "stream",
+			"size":           "1.50 KiB",
+			"messages_count": 10,
+			"topics_count":   2,
+		}})
+	})
+	client := newTestClient(t, mux)
+
+	if _, err := client.GetStreams(); !errors.Is(err, ierror.Unauthenticated) {
+		t.Fatalf("expected %v before login, got %v", ierror.Unauthenticated, err)
+	}
+
+	identity, err := client.LoginUser("iggy", "secret")
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	if identity.UserId != 1 || identity.AccessToken == nil || *identity.AccessToken != "token-1" {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	streams, err := client.GetStreams()
+	if err != nil {
+		t.Fatalf("failed to get streams: %v", err)
+	}
+	expected := Stream{
+		Id:            1,
+		Name:          "stream",
+		SizeBytes:     1536,
+		CreatedAt:     1700000000000000,
+		MessagesCount: 10,
+		TopicsCount:   2,
+	}
+	if len(streams) != 1 || streams[0] != expected {
+		t.Fatalf("expected %+v, got %+v", expected, streams)
+	}
+}
+
+func TestAccessToken_IsRefreshedBeforeExpiry(t *testing.T) {
+	var refreshes atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token-1", time.Now().Add(30*time.Second)))
+	})
+	mux.HandleFunc("POST /users/refresh-token", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request["token"] != "token-1" {
+			writeJson(t, w, http.StatusUnauthorized, map[string]any{"id": 78, "code": "invalid_access_token"})
+			return
+		}
+		refreshes.Add(1)
+		writeJson(t, w, http.StatusOK, identityResponse("token-2", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("DELETE /streams/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			writeJson(t, w, http.StatusUnauthorized, map[string]any{"id": 40, "code": "unauthenticated"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newTestClient(t, mux, WithTokenRefreshThreshold(time.Minute))
+
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	for range 2 {
+		if err := client.DeleteStream(NewIdentifier(1)); err != nil {
+			t.Fatalf("failed to delete the stream: %v", err)
+		}
+	}
+	if refreshes.Load() != 1 {
+		t.Fatalf("expected the token to be refreshed once, got %d", refreshes.Load())
+	}
+}
+
+func TestErrorResponse_IsMappedToIggyError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("GET /streams/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusNotFound, map[string]any{
+			"id":     1009,
+			"code":   "stream_id_not_found",
+			"reason": "Stream with ID: " + r.PathValue("id") + " was not found.",
+			"field":  "stream_id",
+		})
+	})
+	mux.HandleFunc("GET /streams/{id}/topics", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusNotFound, map[string]any{"id": 404, "code": "not_found", "reason": "Resource not found"})
+	})
+	mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusForbidden, map[string]any{"id": 41, "code": "unauthorized"})
+	})
+	mux.HandleFunc("GET /clients", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("bad gateway"))
+	})
+	client := newTestClient(t, mux)
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	_, err := client.GetStream(NewIdentifier(7))
+	assertIggyError(t, err, ierror.StreamIdNotFound.Code, ierror.StreamIdNotFound.Message)
+
+	_, err = client.GetTopics(NewIdentifier(7))
+	assertIggyError(t, err, ierror.ResourceNotFound.Code, ierror.ResourceNotFound.Message)
+
+	_, err = client.GetUsers()
+	assertIggyError(t, err, ierror.Unauthorized.Code, ierror.Unauthorized.Message)
+
+	_, err = client.GetClients()
+	assertIggyError(t, err, 300, "http_response_error (status: 502, body: bad gateway)")
+}
+
+func assertIggyError(t *testing.T, err error, code int, message string) {
+	t.Helper()
+	var iggyErr *ierror.IggyError
+	if !errors.As(err, &iggyErr) {
+		t.Fatalf("expected an IggyError, got %v", err)
+	}
+	if iggyErr.Code != code || iggyErr.Message != message {
+		t.Fatalf("expected %d: '%s', got %v", code, message, iggyErr)
+	}
+}
+
+func TestSendAndPollMessages(t *testing.T) {
+	headerKey, _ := NewHeaderKey("key")
+	message, err := NewIggyMessage([]byte("payload"),
+		WithID([16]byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}),
+		WithUserHeaders(map[HeaderKey]HeaderValue{headerKey: {Kind: String, Value: []byte("value")}}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create the message: %v", err)
+	}
+	// 2^120 + 1, the ID above interpreted as a little-endian 128-bit number.
+	const messageIdNumber = "1329227995784915872903807060280344577"
+
+	var sentMessages json.RawMessage
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("POST /streams/{stream}/topics/{topic}/messages", func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Partitioning map[string]any  `json:"partitioning"`
+			Messages     json.RawMessage `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode the request: %v", err)
+		}
+		if request.Partitioning["kind"] != "partition_id" || request.Partitioning["value"] != "AwAAAA==" {
+			t.Errorf("unexpected partitioning: %v", request.Partitioning)
+		}
+		sentMessages = request.Messages
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("GET /streams/{stream}/topics/{topic}/messages", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("id") != "5" || query.Get("kind") != "offset" || query.Get("value") != "0" ||
+			query.Get("count") != "10" || query.Get("auto_commit") != "true" || query.Get("partition_id") != "3" {
+			t.Errorf("unexpected query: %v", query)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"partition_id":3,"current_offset":0,"count":1,"messages":[{"header":{"checksum":42,` +
+			`"id":` + messageIdNumber + `,"offset":0,"timestamp":100,"origin_timestamp":99,"user_headers_length":21,` +
+			`"payload_length":7},"payload":"cGF5bG9hZA==","user_headers":{"key":{"kind":"string","value":"dmFsdWU="}}}]}`))
+	})
+	client := newTestClient(t, mux)
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	err = client.SendMessages(NewIdentifier("stream"), NewIdentifier("topic"), PartitionId(3), []IggyMessage{message})
+	if err != nil {
+		t.Fatalf("failed to send messages: %v", err)
+	}
+	expectedMessages := `[{"id":` + messageIdNumber + `,"payload":"cGF5bG9hZA==","headers":{"key":{"kind":"string","value":"dmFsdWU="}}}]`
+	if string(sentMessages) != expectedMessages {
+		t.Fatalf("expected %s, got %s", expectedMessages, sentMessages)
+	}
+
+	partitionId := uint32(3)
+	polled, err := client.PollMessages(
+		NewIdentifier("stream"),
+		NewIdentifier("topic"),
+		Consumer{Kind: ConsumerKindSingle, Id: NewIdentifier(5)},
+		OffsetPollingStrategy(0),
+		10,
+		true,
+		&partitionId,
+	)
+	if err != nil {
+		t.Fatalf("failed to poll messages: %v", err)
+	}
+	if polled.PartitionId != 3 || polled.MessageCount != 1 || len(polled.Messages) != 1 {
+		t.Fatalf("unexpected polled messages: %+v", polled)
+	}
+	received := polled.Messages[0]
+	if received.Header.Id != message.Header.Id || string(received.Payload) != "payload" ||
+		received.Header.Checksum != 42 || received.Header.Timestamp != 100 {
+		t.Fatalf("unexpected message: %+v", received)
+	}
+	if string(received.UserHeaders) != string(message.UserHeaders) ||
+		received.Header.UserHeaderLength != uint32(len(message.UserHeaders)) {
+		t.Fatalf("expected user headers %v, got %v", message.UserHeaders, received.UserHeaders)
+	}
+}
+
+func TestByteSize_UnmarshalJSON(t *testing.T) {
+	tests := map[string]uint64{
+		`1024`:       1024,
+		`"0 B"`:      0,
+		`"12 B"`:     12,
+		`"1.50 KiB"`: 1536,
+		`"3 GiB"`:    3 << 30,
+		`"1.5 kb"`:   1500,
+		`"1.000 GB"`: 1000000000,
+	}
+	for input, expected := range tests {
+		var size byteSize
+		if err := json.Unmarshal([]byte(input), &size); err != nil {
+			t.Errorf("failed to unmarshal %s: %v", input, err)
+			continue
+		}
+		if uint64(size) != expected {
+			t.Errorf("%s: expected %s, got %d", input, strconv.FormatUint(expected, 10), size)
+		}
+	}
+
+	for _, input := range []string{`"invalid"`, `"1 XB"`, `"1 2 3"`, `true`} {
+		var size byteSize
+		if err := json.Unmarshal([]byte(input), &size); err == nil {
+			t.Errorf("expected an error for %s", input)
+		}
+	}
+}
+
+func TestDeleteConsumerOffset_SendsPartitionInQuery(t *testing.T) {
+	var deleted string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("DELETE /streams/{stream}/topics/{topic}/consumer-offsets/{consumer}", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.URL.RequestURI()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newTestClient(t, mux)
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	partitionId := uint32(1)
+	consumer := Consumer{Kind: ConsumerKindSingle, Id: NewIdentifier(3)}
+	if err := client.DeleteConsumerOffset(consumer, NewIdentifier(1), NewIdentifier("topic"), &partitionId); err != nil {
+		t.Fatalf("failed to delete the offset: %v", err)
+	}
+	if deleted != "/streams/1/topics/topic/consumer-offsets/3?partition_id=1" {
+		t.Fatalf("unexpected request: %s", deleted)
+	}
+
+	if err := client.DeleteConsumerOffset(consumer, NewIdentifier(1), NewIdentifier(2), nil); err != nil {
+		t.Fatalf("failed to delete the offset: %v", err)
+	}
+	if deleted != "/streams/1/topics/2/consumer-offsets/3" {
+		t.Fatalf("unexpected request: %s", deleted)
+	}
+}
+
+func TestGetSnapshot_ReturnsArchive(t *testing.T) {
+	archive := []byte("PK\x03\x04archive")
+	var request string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("POST /snapshot", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request = string(body)
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(archive)
+	})
+	client := newTestClient(t, mux)
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	snapshot, err := client.GetSnapshot(SnapshotDeflated, []SnapshotType{SnapshotFilesystemOverview, SnapshotServerLogs})
+	if err != nil {
+		t.Fatalf("failed to get the snapshot: %v", err)
+	}
+	if request != `{"compression":"Deflated","snapshot_types":["FilesystemOverview","ServerLogs"]}` {
+		t.Errorf("unexpected request: %s", request)
+	}
+	var written bytes.Buffer
+	if _, err := snapshot.WriteTo(&written); err != nil || !bytes.Equal(written.Bytes(), archive) {
+		t.Fatalf("expected the archive to be written as is, got %q (%v)", written.Bytes(), err)
+	}
+}
+
+func TestCreateTopic_SendsTopicConfig(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("POST /streams/1/topics", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		// The sentinels are written as raw numbers, as the server does.
+		_, _ = io.WriteString(w, `{"id":1,"name":"topic","message_expiry":18446744073709551615,"max_topic_size":0,"partitions":[]}`)
+	})
+	client := newTestClient(t, mux)
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	topic, err := client.CreateTopic(NewIdentifier(1), "topic", 3, TopicConfig{
+		CompressionAlgorithm: CompressionGzip,
+		MessageExpiry:        ExpiryNever,
+		MaxTopicSize:         MaxTopicSizeServerDefault,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create the topic: %v", err)
+	}
+	expected := `{"topic_id":null,"partitions_count":3,"compression_algorithm":"gzip","message_expiry":18446744073709551615,"max_topic_size":0,"replication_factor":null,"name":"topic"}`
+	if len(requests) != 1 || requests[0] != expected {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+	if topic.MessageExpiry != ExpiryNever || topic.MaxTopicSize != MaxTopicSizeServerDefault {
+		t.Errorf("expected the sentinels to be kept, got the expiry %d and the max size %d", topic.MessageExpiry, topic.MaxTopicSize)
+	}
+
+	if _, err := client.CreateTopic(NewIdentifier(1), "", 1, TopicConfig{}, nil); !errors.Is(err, ierror.InvalidTopicName) {
+		t.Errorf("expected an empty name to be rejected, got %v", err)
+	}
+	if _, err := client.CreateTopic(NewIdentifier(1), strings.Repeat("t", MaxTopicNameLength+1), 1, TopicConfig{}, nil); err == nil {
+		t.Error("expected a name too long to be rejected")
+	}
+	if _, err := client.CreateTopic(NewIdentifier(1), "topic", 1, TopicConfig{CompressionAlgorithm: 3}, nil); err == nil {
+		t.Error("expected an unknown compression algorithm to be rejected")
+	}
+	if _, err := client.CreateTopic(NewIdentifier(1), "topic", MaxPartitionsCount+1, TopicConfig{}, nil); !errors.Is(err, ierror.TooManyPartitions) {
+		t.Errorf("expected too many partitions to be rejected, got %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected invalid topics not to reach the server, got %d requests", len(requests))
+	}
+}
+
+func TestPurgeTopic_ReportsUnsupportedCommand(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusOK, identityResponse("token", time.Now().Add(time.Hour)))
+	})
+	mux.HandleFunc("DELETE /streams/{stream}/topics/{topic}/purge", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(t, w, http.StatusBadRequest, map[string]any{"id": 3, "code": "invalid_command"})
+	})
+	client := newTestClient(t, mux)
+	if _, err := client.LoginUser("iggy", "secret"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	err := client.PurgeTopic(NewIdentifier(1), NewIdentifier(2))
+	var unsupported *UnsupportedCommandError
+	if !errors.As(err, &unsupported) || unsupported.Command != PurgeTopicCode {
+		t.Fatalf("expected the command to be unsupported, got %v", err)
+	}
+	var iggyErr *ierror.IggyError
+	if !errors.As(err, &iggyErr) || iggyErr.Code != ierror.InvalidCommand.Code {
+		t.Fatalf("expected the error of the server to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func (c *IggyHttpClient) GetClients() ([]ClientInfo, error) {
+	return c.GetClientsCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) GetClientsCtx(ctx context.Context) ([]ClientInfo, error) {
+	var response []clientInfoResponse
+	if err := c.get(ctx, GetClientsCode, "/clients", nil, &response); err != nil {
+		return nil, err
+	}
+
+	clients := make([]ClientInfo, 0, len(response))
+	for _, client := range response {
+		clients = append(clients, client.toContract())
+	}
+	return clients, nil
+}
+
+// GetMe is not supported, as the HTTP API has no endpoint for the current client.
+func (c *IggyHttpClient) GetMe() (*ClientInfoDetails, error) {
+	return c.GetMeCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) GetMeCtx(context.Context) (*ClientInfoDetails, error) {
+	return nil, ierror.FeatureUnavailable
+}
+
+func (c *IggyHttpClient) GetClient(clientId int) (*ClientInfoDetails, error) {
+	return c.GetClientCtx(c.ctx, clientId)
+}
+
+func (c *IggyHttpClient) GetClientCtx(ctx context.Context, clientId int) (*ClientInfoDetails, error) {
+	var response clientInfoDetailsResponse
+	if err := c.get(ctx, GetClientCode, pathOf("clients", clientId), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
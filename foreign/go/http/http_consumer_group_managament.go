@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func (c *IggyHttpClient) GetConsumerGroups(streamId Identifier, topicId Identifier) ([]ConsumerGroup, error) {
+	return c.GetConsumerGroupsCtx(c.ctx, streamId, topicId)
+}
+
+func (c *IggyHttpClient) GetConsumerGroupsCtx(ctx context.Context, streamId Identifier, topicId Identifier) ([]ConsumerGroup, error) {
+	var response []consumerGroupResponse
+	if err := c.get(ctx, GetGroupsCode, pathOf("streams", streamId, "topics", topicId, "consumer-groups"), nil, &response); err != nil {
+		return nil, err
+	}
+
+	groups := make([]ConsumerGroup, 0, len(response))
+	for _, group := range response {
+		groups = append(groups, group.toContract())
+	}
+	return groups, nil
+}
+
+func (c *IggyHttpClient) GetConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) (*ConsumerGroupDetails, error) {
+	return c.GetConsumerGroupCtx(c.ctx, streamId, topicId, groupId)
+}
+
+func (c *IggyHttpClient) GetConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) (*ConsumerGroupDetails, error) {
+	var response consumerGroupDetailsResponse
+	err := c.get(ctx, GetGroupCode, pathOf("streams", streamId, "topics", topicId, "consumer-groups", groupId), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+func (c *IggyHttpClient) CreateConsumerGroup(streamId Identifier, topicId Identifier, name string, groupId *uint32) (*ConsumerGroupDetails, error) {
+	return c.CreateConsumerGroupCtx(c.ctx, streamId, topicId, name, groupId)
+}
+
+func (c *IggyHttpClient) CreateConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, name string, groupId *uint32) (*ConsumerGroupDetails, error) {
+	if MaxStringLength < len(name) {
+		return nil, ierror.TextTooLong("consumer_group_name")
+	}
+	var response consumerGroupDetailsResponse
+	err := c.post(ctx, CreateGroupCode,
+		pathOf("streams", streamId, "topics", topicId, "consumer-groups"),
+		createConsumerGroupRequest{GroupId: groupId, Name: name},
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+func (c *IggyHttpClient) DeleteConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return c.DeleteConsumerGroupCtx(c.ctx, streamId, topicId, groupId)
+}
+
+func (c *IggyHttpClient) DeleteConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return c.delete(ctx, DeleteGroupCode, pathOf("streams", streamId, "topics", topicId, "consumer-groups", groupId), nil)
+}
+
+// JoinConsumerGroup is not supported by the HTTP transport, as it is stateless.
+func (c *IggyHttpClient) JoinConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return c.JoinConsumerGroupCtx(c.ctx, streamId, topicId, groupId)
+}
+
+func (c *IggyHttpClient) JoinConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return ierror.FeatureUnavailable
+}
+
+// LeaveConsumerGroup is not supported by the HTTP transport, as it is stateless.
+func (c *IggyHttpClient) LeaveConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return c.LeaveConsumerGroupCtx(c.ctx, streamId, topicId, groupId)
+}
+
+func (c *IggyHttpClient) LeaveConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return ierror.FeatureUnavailable
+}
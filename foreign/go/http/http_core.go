@@ -0,0 +1,393 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+type Option func(config *Options)
+
+type Options struct {
+	Ctx                   context.Context
+	ApiUrl                string
+	HttpClient            *http.Client
+	TokenRefreshThreshold time.Duration
+	LogoutOnClose         bool
+	Interceptors          []CommandInterceptor
+}
+
+func GetDefaultOptions() Options {
+	return Options{
+		Ctx:                   context.Background(),
+		ApiUrl:                "http://127.0.0.1:3000",
+		HttpClient:            http.DefaultClient,
+		TokenRefreshThreshold: time.Minute,
+	}
+}
+
+const MaxStringLength = 255
+
+// publicPaths lists the endpoints which do not require an access token.
+var publicPaths = map[string]bool{
+	"/":                             true,
+	"/metrics":                      true,
+	"/ping":                         true,
+	"/stats":                        true,
+	"/users/login":                  true,
+	"/users/refresh-token":          true,
+	"/personal-access-tokens/login": true,
+}
+
+type IggyHttpClient struct {
+	ctx                   context.Context
+	cancel                context.CancelFunc
+	closing               atomic.Bool
+	closed                atomic.Bool
+	logoutOnClose         bool
+	logoutTimeout         time.Duration
+	apiUrl                *url.URL
+	client                *http.Client
+	tokenMtx              sync.Mutex
+	accessToken           string
+	accessTokenExpiry     time.Time
+	tokenRefreshThreshold time.Duration
+	interceptors          []CommandInterceptor
+	serverVersion         atomic.Pointer[ServerVersion]
+}
+
+// WithApiUrl Sets the base URL of the server REST API, e.g. http://127.0.0.1:3000.
+func WithApiUrl(apiUrl string) Option {
+	return func(opts *Options) {
+		opts.ApiUrl = apiUrl
+	}
+}
+
+// WithContext sets context
+func WithContext(ctx context.Context) Option {
+	return func(opts *Options) {
+		opts.Ctx = ctx
+	}
+}
+
+// WithHttpClient sets the underlying http.Client, e.g. to configure TLS, proxies or timeouts.
+func WithHttpClient(client *http.Client) Option {
+	return func(opts *Options) {
+		opts.HttpClient = client
+	}
+}
+
+// logoutOnCloseTimeout bounds the logout done by Close, so that a server which stopped responding does not block it.
+const logoutOnCloseTimeout = 5 * time.Second
+
+// WithLogoutOnClose makes Close log the user out.
+func WithLogoutOnClose() Option {
+	return func(opts *Options) {
+		opts.LogoutOnClose = true
+	}
+}
+
+// WithInterceptors appends interceptors wrapping every command, they see the JSON request and response bodies.
+func WithInterceptors(interceptors ...CommandInterceptor) Option {
+	return func(opts *Options) {
+		opts.Interceptors = append(opts.Interceptors, interceptors...)
+	}
+}
+
+// WithRetryPolicy retries the commands failing with a transient error, see RetryPolicy.
+// It is added to the interceptors, so the interceptors set before it see a single call per command.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return WithInterceptors(RetryCommands(policy))
+}
+
+// WithTokenRefreshThreshold sets how long before its expiry the access token is refreshed.
+func WithTokenRefreshThreshold(threshold time.Duration) Option {
+	return func(opts *Options) {
+		opts.TokenRefreshThreshold = threshold
+	}
+}
+
+func NewIggyHttpClient(options ...Option) (*IggyHttpClient, error) {
+	opts := GetDefaultOptions()
+	for _, opt := range options {
+		if opt != nil {
+			opt(&opts)
+		}
+	}
+	apiUrl, err := url.Parse(strings.TrimSuffix(opts.ApiUrl, "/"))
+	if err != nil || apiUrl.Scheme == "" || apiUrl.Host == "" {
+		return nil, ierror.CannotParseUrl
+	}
+	client := opts.HttpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithCancel(opts.Ctx)
+	c := &IggyHttpClient{
+		ctx:                   ctx,
+		cancel:                cancel,
+		logoutOnClose:         opts.LogoutOnClose,
+		logoutTimeout:         logoutOnCloseTimeout,
+		apiUrl:                apiUrl,
+		client:                client,
+		tokenRefreshThreshold: opts.TokenRefreshThreshold,
+	}
+	c.interceptors = append(slices.Clip(opts.Interceptors), RequireCapabilities(c.ServerVersion))
+	return c, nil
+}
+
+func (c *IggyHttpClient) get(ctx context.Context, command CommandCode, path string, query url.Values, result any) error {
+	return c.send(ctx, command, http.MethodGet, path, query, nil, result)
+}
+
+func (c *IggyHttpClient) post(ctx context.Context, command CommandCode, path string, payload any, result any) error {
+	return c.send(ctx, command, http.MethodPost, path, nil, payload, result)
+}
+
+func (c *IggyHttpClient) put(ctx context.Context, command CommandCode, path string, payload any) error {
+	return c.send(ctx, command, http.MethodPut, path, nil, payload, nil)
+}
+
+func (c *IggyHttpClient) delete(ctx context.Context, command CommandCode, path string, query url.Values) error {
+	return c.send(ctx, command, http.MethodDelete, path, query, nil, nil)
+}
+
+func (c *IggyHttpClient) send(ctx context.Context, command CommandCode, method string, path string, query url.Values, payload any, result any) error {
+	if c.closed.Load() {
+		return ierror.ClientShutdown
+	}
+
+	// Closing the client interrupts the requests in flight.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(c.ctx, cancel)
+	defer stop()
+
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+	invoke := ChainInterceptors(func(ctx context.Context, _ CommandCode, body []byte) ([]byte, error) {
+		return c.exchange(ctx, method, path, query, body)
+	}, c.interceptors...)
+	response, err := invoke(ctx, command, body)
+	if err != nil {
+		if c.closed.Load() {
+			return ierror.ClientShutdown
+		}
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+	// The binary responses, such as the snapshot archives, are returned as is.
+	if raw, ok := result.(*[]byte); ok {
+		*raw = response
+		return nil
+	}
+	if err := json.Unmarshal(response, result); err != nil {
+		return ierror.InvalidJsonResponse
+	}
+	return nil
+}
+
+// exchange sends the request and returns the response body.
+func (c *IggyHttpClient) exchange(ctx context.Context, method string, path string, query url.Values, body []byte) ([]byte, error) {
+	token, err := c.authorize(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload any
+	if body != nil {
+		payload = json.RawMessage(body)
+	}
+	response, err := c.do(ctx, method, path, query, payload, token)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return io.ReadAll(response.Body)
+}
+
+func (c *IggyHttpClient) do(ctx context.Context, method string, path string, query url.Values, payload any, token string) (*http.Response, error) {
+	requestUrl := c.apiUrl.String() + path
+	if len(query) > 0 {
+		requestUrl += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+		return nil, mapErrorResponse(response)
+	}
+
+	return response, nil
+}
+
+// errorResponse is the body returned by the server for failed requests.
+type errorResponse struct {
+	Id     int     `json:"id"`
+	Code   string  `json:"code"`
+	Reason string  `json:"reason"`
+	Field  *string `json:"field"`
+}
+
+func mapErrorResponse(response *http.Response) error {
+	body, _ := io.ReadAll(response.Body)
+
+	var errResponse errorResponse
+	// The generic "not found" response uses the HTTP status as its ID, which is not an iggy error code.
+	if err := json.Unmarshal(body, &errResponse); err == nil && errResponse.Code != "" && errResponse.Id != http.StatusNotFound {
+		return &ierror.IggyError{
+			Code:    errResponse.Id,
+			Message: errResponse.Code,
+		}
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized:
+		return ierror.Unauthenticated
+	case http.StatusForbidden:
+		return ierror.Unauthorized
+	case http.StatusNotFound:
+		return ierror.ResourceNotFound
+	default:
+		return ierror.HttpResponseError(response.StatusCode, string(body))
+	}
+}
+
+// authorize returns the access token for the given path, refreshing it when it is about to expire.
+func (c *IggyHttpClient) authorize(ctx context.Context, path string) (string, error) {
+	c.tokenMtx.Lock()
+	defer c.tokenMtx.Unlock()
+
+	if publicPaths[path] {
+		return c.accessToken, nil
+	}
+	if c.accessToken == "" {
+		return "", ierror.Unauthenticated
+	}
+	if !c.accessTokenExpiry.IsZero() && time.Until(c.accessTokenExpiry) <= c.tokenRefreshThreshold {
+		if err := c.refreshAccessToken(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return c.accessToken, nil
+}
+
+// refreshAccessToken exchanges the current access token for a new one. The caller must hold tokenMtx.
+func (c *IggyHttpClient) refreshAccessToken(ctx context.Context) error {
+	if c.accessToken == "" {
+		return ierror.AccessTokenMissing
+	}
+
+	response, err := c.do(ctx, http.MethodPost, "/users/refresh-token", nil, refreshTokenRequest{Token: c.accessToken}, "")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	var identity identityInfo
+	if err := json.NewDecoder(response.Body).Decode(&identity); err != nil {
+		return ierror.InvalidJsonResponse
+	}
+
+	return c.setAccessToken(identity)
+}
+
+// setAccessToken stores the access token of the identity. The caller must hold tokenMtx.
+func (c *IggyHttpClient) setAccessToken(identity identityInfo) error {
+	if identity.AccessToken == nil || identity.AccessToken.Token == "" {
+		return ierror.JwtMissing
+	}
+	c.accessToken = identity.AccessToken.Token
+	c.accessTokenExpiry = tokenExpiry(identity.AccessToken.Expiry)
+	return nil
+}
+
+// maxTokenExpiry is the last second of year 9999, anything above is treated as a token which never expires.
+const maxTokenExpiry = 253402300799
+
+func tokenExpiry(expiry uint64) time.Time {
+	if expiry == 0 || expiry > maxTokenExpiry {
+		return time.Time{}
+	}
+	return time.Unix(int64(expiry), 0)
+}
+
+// pathOf builds a request path from the given segments, escaping the identifiers.
+func pathOf(segments ...any) string {
+	var builder strings.Builder
+	for _, segment := range segments {
+		builder.WriteByte('/')
+		switch value := segment.(type) {
+		case Identifier:
+			builder.WriteString(url.PathEscape(fmt.Sprint(value.Value)))
+		case string:
+			builder.WriteString(value)
+		default:
+			builder.WriteString(fmt.Sprint(value))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,108 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func (c *IggyHttpClient) SendMessages(
+	streamId Identifier,
+	topicId Identifier,
+	partitioning Partitioning,
+	messages []IggyMessage,
+) error {
+	return c.SendMessagesCtx(c.ctx, streamId, topicId, partitioning, messages)
+}
+
+func (c *IggyHttpClient) SendMessagesCtx(
+	ctx context.Context,
+	streamId Identifier,
+	topicId Identifier,
+	partitioning Partitioning,
+	messages []IggyMessage,
+) error {
+	if len(messages) == 0 {
+		return ierror.CustomError("messages_count_should_be_greater_than_zero")
+	}
+	request, err := newSendMessagesRequest(partitioning, messages)
+	if err != nil {
+		return err
+	}
+
+	return c.post(ctx, SendMessagesCode, pathOf("streams", streamId, "topics", topicId, "messages"), request, nil)
+}
+
+func (c *IggyHttpClient) FlushUnsavedBuffer(streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error {
+	return c.FlushUnsavedBufferCtx(c.ctx, streamId, topicId, partitionId, fsync)
+}
+
+func (c *IggyHttpClient) FlushUnsavedBufferCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error {
+	return c.get(ctx, FlushUnsavedBufferCode, pathOf("streams", streamId, "topics", topicId, "messages", "flush", partitionId, fsync), nil, nil)
+}
+
+// PollMessages polls the messages as a regular consumer, the HTTP API does not support polling on behalf of a consumer group.
+func (c *IggyHttpClient) PollMessages(
+	streamId Identifier,
+	topicId Identifier,
+	consumer Consumer,
+	strategy PollingStrategy,
+	count uint32,
+	autoCommit bool,
+	partitionId *uint32,
+) (*PolledMessage, error) {
+	return c.PollMessagesCtx(c.ctx, streamId, topicId, consumer, strategy, count, autoCommit, partitionId)
+}
+
+func (c *IggyHttpClient) PollMessagesCtx(
+	ctx context.Context,
+	streamId Identifier,
+	topicId Identifier,
+	consumer Consumer,
+	strategy PollingStrategy,
+	count uint32,
+	autoCommit bool,
+	partitionId *uint32,
+) (*PolledMessage, error) {
+	kind, ok := pollingKindNames[strategy.Kind]
+	if !ok {
+		return nil, fmt.Errorf("invalid polling strategy kind: %d", strategy.Kind)
+	}
+	query := url.Values{}
+	query.Set("id", fmt.Sprint(consumer.Id.Value))
+	if partitionId != nil {
+		query.Set("partition_id", strconv.FormatUint(uint64(*partitionId), 10))
+	}
+	query.Set("kind", kind)
+	query.Set("value", strconv.FormatUint(strategy.Value, 10))
+	query.Set("count", strconv.FormatUint(uint64(count), 10))
+	query.Set("auto_commit", strconv.FormatBool(autoCommit))
+
+	var response polledMessagesResponse
+	if err := c.get(ctx, PollMessagesCode, pathOf("streams", streamId, "topics", topicId, "messages"), query, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toContract()
+}
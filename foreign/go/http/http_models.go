@@ -0,0 +1,904 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// The types below mirror the JSON documents of the server REST API, which uses snake_case field names
+// and a few representations (byte sizes, enums as strings, 128-bit message IDs) that differ from the contracts.
+
+type byteSize uint64
+
+var byteUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"tb":  1e12,
+	"tib": 1 << 40,
+	"pb":  1e15,
+	"pib": 1 << 50,
+	"eb":  1e18,
+	"eib": 1 << 60,
+}
+
+// UnmarshalJSON accepts either a number of bytes or a human-readable size such as "1.50 KiB".
+func (s *byteSize) UnmarshalJSON(data []byte) error {
+	var bytes uint64
+	if err := json.Unmarshal(data, &bytes); err == nil {
+		*s = byteSize(bytes)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("invalid byte size: %q", text)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return fmt.Errorf("invalid byte size: %q", text)
+	}
+	multiplier := 1.0
+	if len(fields) == 2 {
+		unit, ok := byteUnits[strings.ToLower(fields[1])]
+		if !ok {
+			return fmt.Errorf("invalid byte size unit: %q", text)
+		}
+		multiplier = unit
+	}
+	*s = byteSize(value * multiplier)
+	return nil
+}
+
+// messageId is the 128-bit message ID, which the server represents as a JSON number.
+// The bytes are stored in the little-endian order used by the binary protocol.
+type messageId MessageID
+
+func (id messageId) MarshalJSON() ([]byte, error) {
+	bigEndian := make([]byte, len(id))
+	for i := range id {
+		bigEndian[len(id)-1-i] = id[i]
+	}
+	return []byte(new(big.Int).SetBytes(bigEndian).String()), nil
+}
+
+func (id *messageId) UnmarshalJSON(data []byte) error {
+	value, ok := new(big.Int).SetString(string(data), 10)
+	if !ok || value.Sign() < 0 || value.BitLen() > 128 {
+		return fmt.Errorf("invalid message ID: %s", data)
+	}
+	bigEndian := value.FillBytes(make([]byte, len(id)))
+	for i := range id {
+		id[i] = bigEndian[len(id)-1-i]
+	}
+	return nil
+}
+
+type streamResponse struct {
+	Id            uint32   `json:"id"`
+	CreatedAt     uint64   `json:"created_at"`
+	Name          string   `json:"name"`
+	Size          byteSize `json:"size"`
+	MessagesCount uint64   `json:"messages_count"`
+	TopicsCount   uint32   `json:"topics_count"`
+}
+
+func (s streamResponse) toContract() Stream {
+	return Stream{
+		Id:            int(s.Id),
+		Name:          s.Name,
+		SizeBytes:     uint64(s.Size),
+		CreatedAt:     s.CreatedAt,
+		MessagesCount: s.MessagesCount,
+		TopicsCount:   int(s.TopicsCount),
+	}
+}
+
+type streamDetailsResponse struct {
+	streamResponse
+	Topics []topicResponse `json:"topics"`
+}
+
+func (s streamDetailsResponse) toContract() *StreamDetails {
+	topics := make([]Topic, 0, len(s.Topics))
+	for _, topic := range s.Topics {
+		topics = append(topics, topic.toContract())
+	}
+	return &StreamDetails{
+		Stream: s.streamResponse.toContract(),
+		Topics: topics,
+	}
+}
+
+type topicResponse struct {
+	Id                   uint32   `json:"id"`
+	CreatedAt            uint64   `json:"created_at"`
+	Name                 string   `json:"name"`
+	Size                 byteSize `json:"size"`
+	MessageExpiry        uint64   `json:"message_expiry"`
+	CompressionAlgorithm string   `json:"compression_algorithm"`
+	MaxTopicSize         uint64   `json:"max_topic_size"`
+	ReplicationFactor    uint8    `json:"replication_factor"`
+	MessagesCount        uint64   `json:"messages_count"`
+	PartitionsCount      uint32   `json:"partitions_count"`
+}
+
+func (t topicResponse) toContract() Topic {
+	return Topic{
+		Id:                   int(t.Id),
+		CreatedAt:            int(t.CreatedAt),
+		Name:                 t.Name,
+		SizeBytes:            uint64(t.Size),
+		MessageExpiry:        IggyExpiry(t.MessageExpiry),
+		CompressionAlgorithm: compressionAlgorithmCode(t.CompressionAlgorithm),
+		MaxTopicSize:         MaxTopicSize(t.MaxTopicSize),
+		ReplicationFactor:    t.ReplicationFactor,
+		MessagesCount:        t.MessagesCount,
+		PartitionsCount:      int(t.PartitionsCount),
+	}
+}
+
+type topicDetailsResponse struct {
+	topicResponse
+	Partitions []partitionResponse `json:"partitions"`
+}
+
+func (t topicDetailsResponse) toContract() *TopicDetails {
+	partitions := make([]PartitionContract, 0, len(t.Partitions))
+	for _, partition := range t.Partitions {
+		partitions = append(partitions, partition.toContract())
+	}
+	return &TopicDetails{
+		Topic:      t.topicResponse.toContract(),
+		Partitions: partitions,
+	}
+}
+
+type partitionResponse struct {
+	Id            uint32   `json:"id"`
+	CreatedAt     uint64   `json:"created_at"`
+	SegmentsCount uint32   `json:"segments_count"`
+	CurrentOffset uint64   `json:"current_offset"`
+	Size          byteSize `json:"size"`
+	MessagesCount uint64   `json:"messages_count"`
+}
+
+func (p partitionResponse) toContract() PartitionContract {
+	return PartitionContract{
+		Id:            int(p.Id),
+		MessagesCount: p.MessagesCount,
+		CreatedAt:     p.CreatedAt,
+		SegmentsCount: int(p.SegmentsCount),
+		CurrentOffset: p.CurrentOffset,
+		SizeBytes:     uint64(p.Size),
+	}
+}
+
+// compressionAlgorithmCode maps the compression algorithm name to the code used by the binary protocol.
+func compressionAlgorithmCode(name string) CompressionAlgorithm {
+	if name == CompressionGzip.String() {
+		return CompressionGzip
+	}
+	return CompressionNone
+}
+
+// replicationFactorOf omits the replication factor the server should choose itself.
+func replicationFactorOf(config TopicConfig) *uint8 {
+	if config.ReplicationFactor == 0 {
+		return nil
+	}
+	return &config.ReplicationFactor
+}
+
+type createStreamRequest struct {
+	StreamId *uint32 `json:"stream_id"`
+	Name     string  `json:"name"`
+}
+
+type updateStreamRequest struct {
+	Name string `json:"name"`
+}
+
+type createTopicRequest struct {
+	TopicId              *uint32 `json:"topic_id"`
+	PartitionsCount      uint32  `json:"partitions_count"`
+	CompressionAlgorithm string  `json:"compression_algorithm"`
+	MessageExpiry        uint64  `json:"message_expiry"`
+	MaxTopicSize         uint64  `json:"max_topic_size"`
+	ReplicationFactor    *uint8  `json:"replication_factor"`
+	Name                 string  `json:"name"`
+}
+
+type updateTopicRequest struct {
+	CompressionAlgorithm string `json:"compression_algorithm"`
+	MessageExpiry        uint64 `json:"message_expiry"`
+	MaxTopicSize         uint64 `json:"max_topic_size"`
+	ReplicationFactor    *uint8 `json:"replication_factor"`
+	Name                 string `json:"name"`
+}
+
+type createPartitionsRequest struct {
+	PartitionsCount uint32 `json:"partitions_count"`
+}
+
+type consumerGroupResponse struct {
+	Id              uint32 `json:"id"`
+	Name            string `json:"name"`
+	PartitionsCount uint32 `json:"partitions_count"`
+	MembersCount    uint32 `json:"members_count"`
+}
+
+func (g consumerGroupResponse) toContract() ConsumerGroup {
+	return ConsumerGroup{
+		Id:              int(g.Id),
+		Name:            g.Name,
+		PartitionsCount: int(g.PartitionsCount),
+		MembersCount:    int(g.MembersCount),
+	}
+}
+
+type consumerGroupMemberResponse struct {
+	Id              uint32   `json:"id"`
+	PartitionsCount uint32   `json:"partitions_count"`
+	Partitions      []uint32 `json:"partitions"`
+}
+
+type consumerGroupDetailsResponse struct {
+	consumerGroupResponse
+	Members []consumerGroupMemberResponse `json:"members"`
+}
+
+func (g consumerGroupDetailsResponse) toContract() *ConsumerGroupDetails {
+	members := make([]ConsumerGroupMember, 0, len(g.Members))
+	for _, member := range g.Members {
+		partitions := make([]int, 0, len(member.Partitions))
+		for _, partition := range member.Partitions {
+			partitions = append(partitions, int(partition))
+		}
+		members = append(members, ConsumerGroupMember{
+			ID:              int(member.Id),
+			PartitionsCount: int(member.PartitionsCount),
+			Partitions:      partitions,
+		})
+	}
+	return &ConsumerGroupDetails{
+		ConsumerGroup: g.consumerGroupResponse.toContract(),
+		Members:       members,
+	}
+}
+
+type createConsumerGroupRequest struct {
+	GroupId *uint32 `json:"group_id"`
+	Name    string  `json:"name"`
+}
+
+type storeConsumerOffsetRequest struct {
+	ConsumerId  string  `json:"id"`
+	PartitionId *uint32 `json:"partition_id"`
+	Offset      uint64  `json:"offset"`
+}
+
+type consumerOffsetInfoResponse struct {
+	PartitionId   uint32 `json:"partition_id"`
+	CurrentOffset uint64 `json:"current_offset"`
+	StoredOffset  uint64 `json:"stored_offset"`
+}
+
+func (o consumerOffsetInfoResponse) toContract() *ConsumerOffsetInfo {
+	return &ConsumerOffsetInfo{
+		PartitionId:   int(o.PartitionId),
+		CurrentOffset: o.CurrentOffset,
+		StoredOffset:  o.StoredOffset,
+	}
+}
+
+var partitioningKindNames = map[PartitioningKind]string{
+	Balanced:        "balanced",
+	PartitionIdKind: "partition_id",
+	MessageKey:      "messages_key",
+}
+
+var pollingKindNames = map[MessagePolling]string{
+	POLLING_OFFSET:    "offset",
+	POLLING_TIMESTAMP: "timestamp",
+	POLLING_FIRST:     "first",
+	POLLING_LAST:      "last",
+	POLLING_NEXT:      "next",
+}
+
+var headerKindNames = map[HeaderKind]string{
+	Raw:     "raw",
+	String:  "string",
+	Bool:    "bool",
+	Int8:    "int8",
+	Int16:   "int16",
+	Int32:   "int32",
+	Int64:   "int64",
+	Int128:  "int128",
+	Uint8:   "uint8",
+	Uint16:  "uint16",
+	Uint32:  "uint32",
+	Uint64:  "uint64",
+	Uint128: "uint128",
+	Float:   "float32",
+	Double:  "float64",
+}
+
+type partitioningRequest struct {
+	Kind  string `json:"kind"`
+	Value []byte `json:"value"`
+}
+
+type headerValue struct {
+	Kind  string `json:"kind"`
+	Value []byte `json:"value"`
+}
+
+type sendMessageRequest struct {
+	Id      messageId              `json:"id"`
+	Payload []byte                 `json:"payload"`
+	Headers map[string]headerValue `json:"headers,omitempty"`
+}
+
+type sendMessagesRequest struct {
+	Partitioning partitioningRequest  `json:"partitioning"`
+	Messages     []sendMessageRequest `json:"messages"`
+}
+
+func newSendMessagesRequest(partitioning Partitioning, messages []IggyMessage) (*sendMessagesRequest, error) {
+	kind, ok := partitioningKindNames[partitioning.Kind]
+	if !ok {
+		return nil, fmt.Errorf("invalid partitioning kind: %d", partitioning.Kind)
+	}
+
+	request := &sendMessagesRequest{
+		Partitioning: partitioningRequest{
+			Kind:  kind,
+			Value: partitioning.Value,
+		},
+		Messages: make([]sendMessageRequest, 0, len(messages)),
+	}
+	for _, message := range messages {
+		headers, err := toHeaderValues(message.UserHeaders)
+		if err != nil {
+			return nil, err
+		}
+		request.Messages = append(request.Messages, sendMessageRequest{
+			Id:      messageId(message.Header.Id),
+			Payload: message.Payload,
+			Headers: headers,
+		})
+	}
+
+	return request, nil
+}
+
+func toHeaderValues(userHeaders []byte) (map[string]headerValue, error) {
+	if len(userHeaders) == 0 {
+		return nil, nil
+	}
+	headers, err := DeserializeHeaders(userHeaders)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]headerValue, len(headers))
+	for key, value := range headers {
+		kind, ok := headerKindNames[value.Kind]
+		if !ok {
+			return nil, fmt.Errorf("invalid header kind: %d", value.Kind)
+		}
+		values[key.Value] = headerValue{
+			Kind:  kind,
+			Value: value.Value,
+		}
+	}
+	return values, nil
+}
+
+func fromHeaderValues(values map[string]headerValue) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	headers := make(map[HeaderKey]HeaderValue, len(values))
+	for key, value := range values {
+		kind, err := headerKindCode(value.Kind)
+		if err != nil {
+			return nil, err
+		}
+		headers[HeaderKey{Value: key}] = HeaderValue{
+			Kind:  kind,
+			Value: value.Value,
+		}
+	}
+	return GetHeadersBytes(headers), nil
+}
+
+func headerKindCode(name string) (HeaderKind, error) {
+	for kind, kindName := range headerKindNames {
+		if kindName == name {
+			return kind, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid header kind: %s", name)
+}
+
+type messageHeaderResponse struct {
+	Checksum          uint64    `json:"checksum"`
+	Id                messageId `json:"id"`
+	Offset            uint64    `json:"offset"`
+	Timestamp         uint64    `json:"timestamp"`
+	OriginTimestamp   uint64    `json:"origin_timestamp"`
+	UserHeadersLength uint32    `json:"user_headers_length"`
+	PayloadLength     uint32    `json:"payload_length"`
+}
+
+type messageResponse struct {
+	Header      messageHeaderResponse  `json:"header"`
+	Payload     []byte                 `json:"payload"`
+	UserHeaders map[string]headerValue `json:"user_headers"`
+}
+
+type polledMessagesResponse struct {
+	PartitionId   uint32            `json:"partition_id"`
+	CurrentOffset uint64            `json:"current_offset"`
+	Count         uint32            `json:"count"`
+	Messages      []messageResponse `json:"messages"`
+}
+
+func (p polledMessagesResponse) toContract() (*PolledMessage, error) {
+	messages := make([]IggyMessage, 0, len(p.Messages))
+	for _, message := range p.Messages {
+		userHeaders, err := fromHeaderValues(message.UserHeaders)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, IggyMessage{
+			Header: MessageHeader{
+				Checksum:         message.Header.Checksum,
+				Id:               MessageID(message.Header.Id),
+				Offset:           message.Header.Offset,
+				Timestamp:        message.Header.Timestamp,
+				OriginTimestamp:  message.Header.OriginTimestamp,
+				UserHeaderLength: uint32(len(userHeaders)),
+				PayloadLength:    uint32(len(message.Payload)),
+			},
+			Payload:     message.Payload,
+			UserHeaders: userHeaders,
+		})
+	}
+	return &PolledMessage{
+		PartitionId:   p.PartitionId,
+		CurrentOffset: p.CurrentOffset,
+		MessageCount:  p.Count,
+		Messages:      messages,
+	}, nil
+}
+
+type loginUserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type loginWithPersonalAccessTokenRequest struct {
+	Token string `json:"token"`
+}
+
+type refreshTokenRequest struct {
+	Token string `json:"token"`
+}
+
+type tokenInfo struct {
+	Token  string `json:"token"`
+	Expiry uint64 `json:"expiry"`
+}
+
+type identityInfo struct {
+	UserId      uint32     `json:"user_id"`
+	AccessToken *tokenInfo `json:"access_token"`
+}
+
+const (
+	userStatusActive   = "active"
+	userStatusInactive = "inactive"
+)
+
+func userStatusName(status UserStatus) string {
+	if status == Inactive {
+		return userStatusInactive
+	}
+	return userStatusActive
+}
+
+func userStatusFromName(name string) (UserStatus, error) {
+	switch name {
+	case userStatusActive:
+		return Active, nil
+	case userStatusInactive:
+		return Inactive, nil
+	default:
+		return 0, fmt.Errorf("invalid user status: %s", name)
+	}
+}
+
+type globalPermissions struct {
+	ManageServers bool `json:"manage_servers"`
+	ReadServers   bool `json:"read_servers"`
+	ManageUsers   bool `json:"manage_users"`
+	ReadUsers     bool `json:"read_users"`
+	ManageStreams bool `json:"manage_streams"`
+	ReadStreams   bool `json:"read_streams"`
+	ManageTopics  bool `json:"manage_topics"`
+	ReadTopics    bool `json:"read_topics"`
+	PollMessages  bool `json:"poll_messages"`
+	SendMessages  bool `json:"send_messages"`
+}
+
+type topicPermissions struct {
+	ManageTopic  bool `json:"manage_topic"`
+	ReadTopic    bool `json:"read_topic"`
+	PollMessages bool `json:"poll_messages"`
+	SendMessages bool `json:"send_messages"`
+}
+
+type streamPermissions struct {
+	ManageStream bool                      `json:"manage_stream"`
+	ReadStream   bool                      `json:"read_stream"`
+	ManageTopics bool                      `json:"manage_topics"`
+	ReadTopics   bool                      `json:"read_topics"`
+	PollMessages bool                      `json:"poll_messages"`
+	SendMessages bool                      `json:"send_messages"`
+	Topics       map[int]*topicPermissions `json:"topics"`
+}
+
+type permissions struct {
+	Global  globalPermissions          `json:"global"`
+	Streams map[int]*streamPermissions `json:"streams"`
+}
+
+func newPermissions(p *Permissions) *permissions {
+	if p == nil {
+		return nil
+	}
+	result := &permissions{
+		Global: globalPermissions(p.Global),
+	}
+	if p.Streams != nil {
+		result.Streams = make(map[int]*streamPermissions, len(p.Streams))
+	}
+	for streamId, stream := range p.Streams {
+		if stream == nil {
+			continue
+		}
+		sp := &streamPermissions{
+			ManageStream: stream.ManageStream,
+			ReadStream:   stream.ReadStream,
+			ManageTopics: stream.ManageTopics,
+			ReadTopics:   stream.ReadTopics,
+			PollMessages: stream.PollMessages,
+			SendMessages: stream.SendMessages,
+		}
+		if stream.Topics != nil {
+			sp.Topics = make(map[int]*topicPermissions, len(stream.Topics))
+		}
+		for topicId, topic := range stream.Topics {
+			if topic == nil {
+				continue
+			}
+			tp := topicPermissions(*topic)
+			sp.Topics[topicId] = &tp
+		}
+		result.Streams[streamId] = sp
+	}
+	return result
+}
+
+func (p *permissions) toContract() *Permissions {
+	if p == nil {
+		return nil
+	}
+	result := &Permissions{
+		Global:  GlobalPermissions(p.Global),
+		Streams: make(map[int]*StreamPermissions, len(p.Streams)),
+	}
+	for streamId, stream := range p.Streams {
+		if stream == nil {
+			continue
+		}
+		sp := &StreamPermissions{
+			ManageStream: stream.ManageStream,
+			ReadStream:   stream.ReadStream,
+			ManageTopics: stream.ManageTopics,
+			ReadTopics:   stream.ReadTopics,
+			PollMessages: stream.PollMessages,
+			SendMessages: stream.SendMessages,
+			Topics:       make(map[int]*TopicPermissions, len(stream.Topics)),
+		}
+		for topicId, topic := range stream.Topics {
+			if topic == nil {
+				continue
+			}
+			tp := TopicPermissions(*topic)
+			sp.Topics[topicId] = &tp
+		}
+		result.Streams[streamId] = sp
+	}
+	return result
+}
+
+type userInfoResponse struct {
+	Id        uint32 `json:"id"`
+	CreatedAt uint64 `json:"created_at"`
+	Status    string `json:"status"`
+	Username  string `json:"username"`
+}
+
+func (u userInfoResponse) toContract() (UserInfo, error) {
+	status, err := userStatusFromName(u.Status)
+	if err != nil {
+		return UserInfo{}, err
+	}
+	return UserInfo{
+		Id:        u.Id,
+		CreatedAt: u.CreatedAt,
+		Status:    status,
+		Username:  u.Username,
+	}, nil
+}
+
+type userInfoDetailsResponse struct {
+	userInfoResponse
+	Permissions *permissions `json:"permissions"`
+}
+
+func (u userInfoDetailsResponse) toContract() (*UserInfoDetails, error) {
+	userInfo, err := u.userInfoResponse.toContract()
+	if err != nil {
+		return nil, err
+	}
+	return &UserInfoDetails{
+		UserInfo:    userInfo,
+		Permissions: u.Permissions.toContract(),
+	}, nil
+}
+
+type createUserRequest struct {
+	Username    string       `json:"username"`
+	Password    string       `json:"password"`
+	Status      string       `json:"status"`
+	Permissions *permissions `json:"permissions"`
+}
+
+type updateUserRequest struct {
+	Username *string `json:"username"`
+	Status   *string `json:"status"`
+}
+
+type updatePermissionsRequest struct {
+	Permissions *permissions `json:"permissions"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type createPersonalAccessTokenRequest struct {
+	Name   string `json:"name"`
+	Expiry uint64 `json:"expiry"`
+}
+
+type personalAccessTokenInfoResponse struct {
+	Name     string  `json:"name"`
+	ExpiryAt *uint64 `json:"expiry_at"`
+}
+
+func (t personalAccessTokenInfoResponse) toContract() PersonalAccessTokenInfo {
+	var expiry *time.Time
+	if t.ExpiryAt != nil {
+		expiryTime := time.UnixMicro(int64(*t.ExpiryAt))
+		expiry = &expiryTime
+	}
+	return PersonalAccessTokenInfo{
+		Name:   t.Name,
+		Expiry: expiry,
+	}
+}
+
+type statsResponse struct {
+	ProcessId           uint32   `json:"process_id"`
+	CpuUsage            float32  `json:"cpu_usage"`
+	TotalCpuUsage       float32  `json:"total_cpu_usage"`
+	MemoryUsage         byteSize `json:"memory_usage"`
+	TotalMemory         byteSize `json:"total_memory"`
+	AvailableMemory     byteSize `json:"available_memory"`
+	RunTime             uint64   `json:"run_time"`
+	StartTime           uint64   `json:"start_time"`
+	ReadBytes           byteSize `json:"read_bytes"`
+	WrittenBytes        byteSize `json:"written_bytes"`
+	MessagesSizeBytes   byteSize `json:"messages_size_bytes"`
+	StreamsCount        uint32   `json:"streams_count"`
+	TopicsCount         uint32   `json:"topics_count"`
+	PartitionsCount     uint32   `json:"partitions_count"`
+	SegmentsCount       uint32   `json:"segments_count"`
+	MessagesCount       uint64   `json:"messages_count"`
+	ClientsCount        uint32   `json:"clients_count"`
+	ConsumerGroupsCount uint32   `json:"consumer_groups_count"`
+	Hostname            string   `json:"hostname"`
+	OsName              string   `json:"os_name"`
+	OsVersion           string   `json:"os_version"`
+	KernelVersion       string   `json:"kernel_version"`
+	IggyServerVersion   string   `json:"iggy_server_version"`
+	IggyServerSemver    uint32   `json:"iggy_server_semver"`
+	// CacheMetrics are keyed by "stream-topic-partition".
+	CacheMetrics map[string]CacheMetrics `json:"cache_metrics"`
+}
+
+func (s statsResponse) toContract() *Stats {
+	return &Stats{
+		ProcessId:           int(s.ProcessId),
+		CpuUsage:            s.CpuUsage,
+		TotalCpuUsage:       s.TotalCpuUsage,
+		MemoryUsage:         uint64(s.MemoryUsage),
+		TotalMemory:         uint64(s.TotalMemory),
+		AvailableMemory:     uint64(s.AvailableMemory),
+		RunTime:             s.RunTime,
+		StartTime:           s.StartTime,
+		ReadBytes:           uint64(s.ReadBytes),
+		WrittenBytes:        uint64(s.WrittenBytes),
+		MessagesSizeBytes:   uint64(s.MessagesSizeBytes),
+		StreamsCount:        int(s.StreamsCount),
+		TopicsCount:         int(s.TopicsCount),
+		PartitionsCount:     int(s.PartitionsCount),
+		SegmentsCount:       int(s.SegmentsCount),
+		MessagesCount:       s.MessagesCount,
+		ClientsCount:        int(s.ClientsCount),
+		ConsumerGroupsCount: int(s.ConsumerGroupsCount),
+		Hostname:            s.Hostname,
+		OsName:              s.OsName,
+		OsVersion:           s.OsVersion,
+		KernelVersion:       s.KernelVersion,
+		IggyServerVersion:   s.IggyServerVersion,
+		IggyServerSemver:    SemanticVersion(s.IggyServerSemver),
+		CacheMetrics:        s.cacheMetrics(),
+	}
+}
+
+// cacheMetrics skips the metrics with malformed keys rather than failing the whole response.
+func (s statsResponse) cacheMetrics() map[CacheMetricsKey]CacheMetrics {
+	if s.CacheMetrics == nil {
+		return nil
+	}
+	metrics := make(map[CacheMetricsKey]CacheMetrics, len(s.CacheMetrics))
+	for text, value := range s.CacheMetrics {
+		var key CacheMetricsKey
+		if err := key.UnmarshalText([]byte(text)); err == nil {
+			metrics[key] = value
+		}
+	}
+	return metrics
+}
+
+type clientInfoResponse struct {
+	ClientId            uint32  `json:"client_id"`
+	UserId              *uint32 `json:"user_id"`
+	Address             string  `json:"address"`
+	Transport           string  `json:"transport"`
+	ConsumerGroupsCount uint32  `json:"consumer_groups_count"`
+}
+
+func (c clientInfoResponse) toContract() ClientInfo {
+	var userId uint32
+	if c.UserId != nil {
+		userId = *c.UserId
+	}
+	transport := c.Transport
+	for _, protocol := range []Protocol{Tcp, Quic, Http} {
+		if strings.EqualFold(transport, string(protocol)) {
+			transport = string(protocol)
+		}
+	}
+	return ClientInfo{
+		ID:                  c.ClientId,
+		Address:             c.Address,
+		UserID:              userId,
+		Transport:           transport,
+		ConsumerGroupsCount: c.ConsumerGroupsCount,
+	}
+}
+
+type consumerGroupInfoResponse struct {
+	StreamId uint32 `json:"stream_id"`
+	TopicId  uint32 `json:"topic_id"`
+	GroupId  uint32 `json:"group_id"`
+}
+
+type clientInfoDetailsResponse struct {
+	clientInfoResponse
+	ConsumerGroups []consumerGroupInfoResponse `json:"consumer_groups"`
+}
+
+func (c clientInfoDetailsResponse) toContract() *ClientInfoDetails {
+	consumerGroups := make([]ConsumerGroupInfo, 0, len(c.ConsumerGroups))
+	for _, group := range c.ConsumerGroups {
+		consumerGroups = append(consumerGroups, ConsumerGroupInfo{
+			StreamId:        int(group.StreamId),
+			TopicId:         int(group.TopicId),
+			ConsumerGroupId: int(group.GroupId),
+		})
+	}
+	return &ClientInfoDetails{
+		ClientInfo:     c.clientInfoResponse.toContract(),
+		ConsumerGroups: consumerGroups,
+	}
+}
+
+type getSnapshotRequest struct {
+	Compression   string   `json:"compression"`
+	SnapshotTypes []string `json:"snapshot_types"`
+}
+
+var snapshotCompressionNames = map[SnapshotCompression]string{
+	SnapshotStored:   "Stored",
+	SnapshotDeflated: "Deflated",
+	SnapshotBzip2:    "Bzip2",
+	SnapshotZstd:     "Zstd",
+	SnapshotLzma:     "Lzma",
+	SnapshotXz:       "Xz",
+}
+
+var snapshotTypeNames = map[SnapshotType]string{
+	SnapshotFilesystemOverview: "FilesystemOverview",
+	SnapshotProcessList:        "ProcessList",
+	SnapshotResourceUsage:      "ResourceUsage",
+	SnapshotTest:               "Test",
+	SnapshotServerLogs:         "ServerLogs",
+	SnapshotServerConfig:       "ServerConfig",
+	SnapshotAll:                "All",
+}
+
+func newGetSnapshotRequest(compression SnapshotCompression, snapshotTypes []SnapshotType) (getSnapshotRequest, error) {
+	compressionName, ok := snapshotCompressionNames[compression]
+	if !ok {
+		return getSnapshotRequest{}, fmt.Errorf("invalid snapshot compression: %d", compression)
+	}
+	request := getSnapshotRequest{Compression: compressionName, SnapshotTypes: make([]string, 0, len(snapshotTypes))}
+	for _, snapshotType := range snapshotTypes {
+		name, ok := snapshotTypeNames[snapshotType]
+		if !ok {
+			return getSnapshotRequest{}, fmt.Errorf("invalid snapshot type: %d", snapshotType)
+		}
+		request.SnapshotTypes = append(request.SnapshotTypes, name)
+	}
+	return request, nil
+}
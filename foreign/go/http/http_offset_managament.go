@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+// GetConsumerOffset returns nil without an error when no offset has been stored yet.
+// The HTTP API always treats the consumer as a regular consumer, regardless of its kind.
+func (c *IggyHttpClient) GetConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) (*ConsumerOffsetInfo, error) {
+	return c.GetConsumerOffsetCtx(c.ctx, consumer, streamId, topicId, partitionId)
+}
+
+func (c *IggyHttpClient) GetConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) (*ConsumerOffsetInfo, error) {
+	query := url.Values{}
+	query.Set("id", fmt.Sprint(consumer.Id.Value))
+	if partitionId != nil {
+		query.Set("partition_id", strconv.FormatUint(uint64(*partitionId), 10))
+	}
+
+	var response consumerOffsetInfoResponse
+	err := c.get(ctx, GetOffsetCode, pathOf("streams", streamId, "topics", topicId, "consumer-offsets"), query, &response)
+	if errors.Is(err, ierror.ResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+// StoreConsumerOffset always stores the offset of a regular consumer, regardless of its kind.
+func (c *IggyHttpClient) StoreConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, offset uint64, partitionId *uint32) error {
+	return c.StoreConsumerOffsetCtx(c.ctx, consumer, streamId, topicId, offset, partitionId)
+}
+
+func (c *IggyHttpClient) StoreConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, offset uint64, partitionId *uint32) error {
+	return c.put(ctx, StoreOffsetCode, pathOf("streams", streamId, "topics", topicId, "consumer-offsets"), storeConsumerOffsetRequest{
+		ConsumerId:  fmt.Sprint(consumer.Id.Value),
+		PartitionId: partitionId,
+		Offset:      offset,
+	})
+}
+
+// DeleteConsumerOffset always deletes the offset of a regular consumer, regardless of its kind.
+func (c *IggyHttpClient) DeleteConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) error {
+	return c.DeleteConsumerOffsetCtx(c.ctx, consumer, streamId, topicId, partitionId)
+}
+
+func (c *IggyHttpClient) DeleteConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) error {
+	query := url.Values{}
+	if partitionId != nil {
+		query.Set("partition_id", strconv.FormatUint(uint64(*partitionId), 10))
+	}
+
+	return c.delete(ctx, DeleteOffsetCode, pathOf("streams", streamId, "topics", topicId, "consumer-offsets", consumer.Id), query)
+}
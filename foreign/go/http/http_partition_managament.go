@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func (c *IggyHttpClient) CreatePartitions(streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return c.CreatePartitionsCtx(c.ctx, streamId, topicId, partitionsCount)
+}
+
+func (c *IggyHttpClient) CreatePartitionsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return c.post(ctx, CreatePartitionsCode,
+		pathOf("streams", streamId, "topics", topicId, "partitions"),
+		createPartitionsRequest{PartitionsCount: partitionsCount},
+		nil,
+	)
+}
+
+func (c *IggyHttpClient) DeletePartitions(streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return c.DeletePartitionsCtx(c.ctx, streamId, topicId, partitionsCount)
+}
+
+func (c *IggyHttpClient) DeletePartitionsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	query := url.Values{}
+	query.Set("partitions_count", strconv.FormatUint(uint64(partitionsCount), 10))
+	return c.delete(ctx, DeletePartitionsCode, pathOf("streams", streamId, "topics", topicId, "partitions"), query)
+}
+
+// DeleteSegments is not supported, as the HTTP API has no endpoint for the segments.
+func (c *IggyHttpClient) DeleteSegments(streamId Identifier, topicId Identifier, partitionId uint32, segmentsCount uint32) error {
+	return c.DeleteSegmentsCtx(c.ctx, streamId, topicId, partitionId, segmentsCount)
+}
+
+func (c *IggyHttpClient) DeleteSegmentsCtx(context.Context, Identifier, Identifier, uint32, uint32) error {
+	return ierror.FeatureUnavailable
+}
@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+	"time"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func (c *IggyHttpClient) LoginUser(username string, password string) (*IdentityInfo, error) {
+	return c.LoginUserCtx(c.ctx, username, password)
+}
+
+func (c *IggyHttpClient) LoginUserCtx(ctx context.Context, username string, password string) (*IdentityInfo, error) {
+	var response identityInfo
+	err := c.post(ctx, LoginUserCode, "/users/login", loginUserRequest{Username: username, Password: password}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.authenticate(ctx, response)
+}
+
+func (c *IggyHttpClient) LoginWithPersonalAccessToken(token string) (*IdentityInfo, error) {
+	return c.LoginWithPersonalAccessTokenCtx(c.ctx, token)
+}
+
+func (c *IggyHttpClient) LoginWithPersonalAccessTokenCtx(ctx context.Context, token string) (*IdentityInfo, error) {
+	var response identityInfo
+	err := c.post(ctx, LoginWithAccessTokenCode, "/personal-access-tokens/login", loginWithPersonalAccessTokenRequest{Token: token}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.authenticate(ctx, response)
+}
+
+func (c *IggyHttpClient) LogoutUser() error {
+	return c.LogoutUserCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) LogoutUserCtx(ctx context.Context) error {
+	if err := c.delete(ctx, LogoutUserCode, "/users/logout", nil); err != nil {
+		return err
+	}
+
+	c.tokenMtx.Lock()
+	defer c.tokenMtx.Unlock()
+	c.accessToken = ""
+	c.accessTokenExpiry = time.Time{}
+	return nil
+}
+
+// Close interrupts the requests in flight, and the later calls fail with ierror.ClientShutdown.
+// With LogoutOnClose, a logged-in user is logged out first, and the error of the logout is returned after the client is closed anyway.
+// The logout is given at most a few seconds, and only the first of concurrent calls does it.
+func (c *IggyHttpClient) Close() error {
+	if !c.closing.CompareAndSwap(false, true) {
+		return ierror.ClientShutdown
+	}
+
+	var err error
+	if c.logoutOnClose && c.loggedIn() {
+		ctx, cancel := context.WithTimeout(c.ctx, c.logoutTimeout)
+		err = c.LogoutUserCtx(ctx)
+		cancel()
+	}
+	c.closed.Store(true)
+	c.cancel()
+	return err
+}
+
+func (c *IggyHttpClient) loggedIn() bool {
+	c.tokenMtx.Lock()
+	defer c.tokenMtx.Unlock()
+	return c.accessToken != ""
+}
+
+func (c *IggyHttpClient) authenticate(ctx context.Context, identity identityInfo) (*IdentityInfo, error) {
+	c.tokenMtx.Lock()
+	err := c.setAccessToken(identity)
+	accessToken := c.accessToken
+	c.tokenMtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	c.detectServerVersion(ctx)
+	return &IdentityInfo{
+		UserId:      identity.UserId,
+		AccessToken: &accessToken,
+	}, nil
+}
@@ -0,0 +1,99 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func (c *IggyHttpClient) GetStreams() ([]Stream, error) {
+	return c.GetStreamsCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) GetStreamsCtx(ctx context.Context) ([]Stream, error) {
+	var response []streamResponse
+	if err := c.get(ctx, GetStreamsCode, "/streams", nil, &response); err != nil {
+		return nil, err
+	}
+
+	streams := make([]Stream, 0, len(response))
+	for _, stream := range response {
+		streams = append(streams, stream.toContract())
+	}
+	return streams, nil
+}
+
+func (c *IggyHttpClient) GetStream(streamId Identifier) (*StreamDetails, error) {
+	return c.GetStreamCtx(c.ctx, streamId)
+}
+
+func (c *IggyHttpClient) GetStreamCtx(ctx context.Context, streamId Identifier) (*StreamDetails, error) {
+	var response streamDetailsResponse
+	if err := c.get(ctx, GetStreamCode, pathOf("streams", streamId), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+func (c *IggyHttpClient) CreateStream(name string, streamId *uint32) (*StreamDetails, error) {
+	return c.CreateStreamCtx(c.ctx, name, streamId)
+}
+
+func (c *IggyHttpClient) CreateStreamCtx(ctx context.Context, name string, streamId *uint32) (*StreamDetails, error) {
+	if MaxStringLength < len(name) {
+		return nil, ierror.TextTooLong("stream_name")
+	}
+	var response streamDetailsResponse
+	err := c.post(ctx, CreateStreamCode, "/streams", createStreamRequest{StreamId: streamId, Name: name}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+func (c *IggyHttpClient) UpdateStream(streamId Identifier, name string) error {
+	return c.UpdateStreamCtx(c.ctx, streamId, name)
+}
+
+func (c *IggyHttpClient) UpdateStreamCtx(ctx context.Context, streamId Identifier, name string) error {
+	if MaxStringLength < len(name) {
+		return ierror.TextTooLong("stream_name")
+	}
+	return c.put(ctx, UpdateStreamCode, pathOf("streams", streamId), updateStreamRequest{Name: name})
+}
+
+func (c *IggyHttpClient) DeleteStream(id Identifier) error {
+	return c.DeleteStreamCtx(c.ctx, id)
+}
+
+func (c *IggyHttpClient) DeleteStreamCtx(ctx context.Context, id Identifier) error {
+	return c.delete(ctx, DeleteStreamCode, pathOf("streams", id), nil)
+}
+
+func (c *IggyHttpClient) PurgeStream(id Identifier) error {
+	return c.PurgeStreamCtx(c.ctx, id)
+}
+
+func (c *IggyHttpClient) PurgeStreamCtx(ctx context.Context, id Identifier) error {
+	return c.delete(ctx, PurgeStreamCode, pathOf("streams", id, "purge"), nil)
+}
@@ -0,0 +1,139 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func (c *IggyHttpClient) GetTopics(streamId Identifier) ([]Topic, error) {
+	return c.GetTopicsCtx(c.ctx, streamId)
+}
+
+func (c *IggyHttpClient) GetTopicsCtx(ctx context.Context, streamId Identifier) ([]Topic, error) {
+	var response []topicResponse
+	if err := c.get(ctx, GetTopicsCode, pathOf("streams", streamId, "topics"), nil, &response); err != nil {
+		return nil, err
+	}
+
+	topics := make([]Topic, 0, len(response))
+	for _, topic := range response {
+		topics = append(topics, topic.toContract())
+	}
+	return topics, nil
+}
+
+func (c *IggyHttpClient) GetTopic(streamId Identifier, topicId Identifier) (*TopicDetails, error) {
+	return c.GetTopicCtx(c.ctx, streamId, topicId)
+}
+
+func (c *IggyHttpClient) GetTopicCtx(ctx context.Context, streamId Identifier, topicId Identifier) (*TopicDetails, error) {
+	var response topicDetailsResponse
+	if err := c.get(ctx, GetTopicCode, pathOf("streams", streamId, "topics", topicId), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+func (c *IggyHttpClient) CreateTopic(
+	streamId Identifier,
+	name string,
+	partitionsCount uint32,
+	config TopicConfig,
+	topicId *int,
+) (*TopicDetails, error) {
+	return c.CreateTopicCtx(c.ctx, streamId, name, partitionsCount, config, topicId)
+}
+
+func (c *IggyHttpClient) CreateTopicCtx(
+	ctx context.Context,
+	streamId Identifier,
+	name string,
+	partitionsCount uint32,
+	config TopicConfig,
+	topicId *int,
+) (*TopicDetails, error) {
+	if err := ValidateCreateTopic(name, partitionsCount, config); err != nil {
+		return nil, err
+	}
+	request := createTopicRequest{
+		PartitionsCount:      partitionsCount,
+		CompressionAlgorithm: config.CompressionAlgorithm.String(),
+		MessageExpiry:        uint64(config.MessageExpiry),
+		MaxTopicSize:         uint64(config.MaxTopicSize),
+		ReplicationFactor:    replicationFactorOf(config),
+		Name:                 name,
+	}
+	if topicId != nil {
+		id := uint32(*topicId)
+		request.TopicId = &id
+	}
+	var response topicDetailsResponse
+	if err := c.post(ctx, CreateTopicCode, pathOf("streams", streamId, "topics"), request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+func (c *IggyHttpClient) UpdateTopic(
+	streamId Identifier,
+	topicId Identifier,
+	name string,
+	config TopicConfig,
+) error {
+	return c.UpdateTopicCtx(c.ctx, streamId, topicId, name, config)
+}
+
+func (c *IggyHttpClient) UpdateTopicCtx(
+	ctx context.Context,
+	streamId Identifier,
+	topicId Identifier,
+	name string,
+	config TopicConfig,
+) error {
+	if err := ValidateUpdateTopic(name, config); err != nil {
+		return err
+	}
+	return c.put(ctx, UpdateTopicCode, pathOf("streams", streamId, "topics", topicId), updateTopicRequest{
+		CompressionAlgorithm: config.CompressionAlgorithm.String(),
+		MessageExpiry:        uint64(config.MessageExpiry),
+		MaxTopicSize:         uint64(config.MaxTopicSize),
+		ReplicationFactor:    replicationFactorOf(config),
+		Name:                 name,
+	})
+}
+
+func (c *IggyHttpClient) DeleteTopic(streamId, topicId Identifier) error {
+	return c.DeleteTopicCtx(c.ctx, streamId, topicId)
+}
+
+func (c *IggyHttpClient) DeleteTopicCtx(ctx context.Context, streamId, topicId Identifier) error {
+	return c.delete(ctx, DeleteTopicCode, pathOf("streams", streamId, "topics", topicId), nil)
+}
+
+func (c *IggyHttpClient) PurgeTopic(streamId, topicId Identifier) error {
+	return c.PurgeTopicCtx(c.ctx, streamId, topicId)
+}
+
+func (c *IggyHttpClient) PurgeTopicCtx(ctx context.Context, streamId, topicId Identifier) error {
+	return c.delete(ctx, PurgeTopicCode, pathOf("streams", streamId, "topics", topicId, "purge"), nil)
+}
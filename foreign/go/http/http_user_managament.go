@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func (c *IggyHttpClient) GetUser(identifier Identifier) (*UserInfoDetails, error) {
+	return c.GetUserCtx(c.ctx, identifier)
+}
+
+func (c *IggyHttpClient) GetUserCtx(ctx context.Context, identifier Identifier) (*UserInfoDetails, error) {
+	var response userInfoDetailsResponse
+	if err := c.get(ctx, GetUserCode, pathOf("users", identifier), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toContract()
+}
+
+func (c *IggyHttpClient) GetUsers() ([]UserInfo, error) {
+	return c.GetUsersCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) GetUsersCtx(ctx context.Context) ([]UserInfo, error) {
+	var response []userInfoResponse
+	if err := c.get(ctx, GetUsersCode, "/users", nil, &response); err != nil {
+		return nil, err
+	}
+
+	users := make([]UserInfo, 0, len(response))
+	for _, user := range response {
+		userInfo, err := user.toContract()
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, userInfo)
+	}
+	return users, nil
+}
+
+func (c *IggyHttpClient) CreateUser(username string, password string, status UserStatus, permissions *Permissions) (*UserInfoDetails, error) {
+	return c.CreateUserCtx(c.ctx, username, password, status, permissions)
+}
+
+func (c *IggyHttpClient) CreateUserCtx(ctx context.Context, username string, password string, status UserStatus, permissions *Permissions) (*UserInfoDetails, error) {
+	var response userInfoDetailsResponse
+	err := c.post(ctx, CreateUserCode, "/users", createUserRequest{
+		Username:    username,
+		Password:    password,
+		Status:      userStatusName(status),
+		Permissions: newPermissions(permissions),
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.toContract()
+}
+
+func (c *IggyHttpClient) UpdateUser(userID Identifier, username *string, status *UserStatus) error {
+	return c.UpdateUserCtx(c.ctx, userID, username, status)
+}
+
+func (c *IggyHttpClient) UpdateUserCtx(ctx context.Context, userID Identifier, username *string, status *UserStatus) error {
+	request := updateUserRequest{Username: username}
+	if status != nil {
+		statusName := userStatusName(*status)
+		request.Status = &statusName
+	}
+	return c.put(ctx, UpdateUserCode, pathOf("users", userID), request)
+}
+
+func (c *IggyHttpClient) UpdatePermissions(userID Identifier, permissions *Permissions) error {
+	return c.UpdatePermissionsCtx(c.ctx, userID, permissions)
+}
+
+func (c *IggyHttpClient) UpdatePermissionsCtx(ctx context.Context, userID Identifier, permissions *Permissions) error {
+	return c.put(ctx, UpdatePermissionsCode, pathOf("users", userID, "permissions"), updatePermissionsRequest{
+		Permissions: newPermissions(permissions),
+	})
+}
+
+func (c *IggyHttpClient) ChangePassword(userID Identifier, currentPassword string, newPassword string) error {
+	return c.ChangePasswordCtx(c.ctx, userID, currentPassword, newPassword)
+}
+
+func (c *IggyHttpClient) ChangePasswordCtx(ctx context.Context, userID Identifier, currentPassword string, newPassword string) error {
+	return c.put(ctx, ChangePasswordCode, pathOf("users", userID, "password"), changePasswordRequest{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+}
+
+func (c *IggyHttpClient) DeleteUser(identifier Identifier) error {
+	return c.DeleteUserCtx(c.ctx, identifier)
+}
+
+func (c *IggyHttpClient) DeleteUserCtx(ctx context.Context, identifier Identifier) error {
+	return c.delete(ctx, DeleteUserCode, pathOf("users", identifier), nil)
+}
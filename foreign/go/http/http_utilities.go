@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ihttp
+
+import (
+	"context"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func (c *IggyHttpClient) GetStats() (*Stats, error) {
+	return c.GetStatsCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) GetStatsCtx(ctx context.Context) (*Stats, error) {
+	var response statsResponse
+	if err := c.get(ctx, GetStatsCode, "/stats", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toContract(), nil
+}
+
+func (c *IggyHttpClient) GetSnapshot(compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error) {
+	return c.GetSnapshotCtx(c.ctx, compression, snapshotTypes)
+}
+
+func (c *IggyHttpClient) GetSnapshotCtx(ctx context.Context, compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error) {
+	request, err := newGetSnapshotRequest(compression, snapshotTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot []byte
+	if err := c.post(ctx, GetSnapshotFileCode, "/snapshot", request, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ServerVersion returns the version of the server detected when logging in, it is unknown before.
+func (c *IggyHttpClient) ServerVersion() ServerVersion {
+	if version := c.serverVersion.Load(); version != nil {
+		return *version
+	}
+	return ServerVersion{}
+}
+
+// detectServerVersion reads the server version from its stats, a failure leaves the version unknown.
+func (c *IggyHttpClient) detectServerVersion(ctx context.Context) {
+	if stats, err := c.GetStatsCtx(ctx); err == nil {
+		version := ServerVersionOf(stats)
+		c.serverVersion.Store(&version)
+	}
+}
+
+func (c *IggyHttpClient) Ping() error {
+	return c.PingCtx(c.ctx)
+}
+
+func (c *IggyHttpClient) PingCtx(ctx context.Context) error {
+	return c.get(ctx, PingCode, "/ping", nil, nil)
+}
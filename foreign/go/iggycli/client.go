@@ -18,12 +18,23 @@
 package iggycli
 
 import (
-	"time"
+	"context"
+	"io"
 
 	. "github.com/apache/iggy/foreign/go/contracts"
 )
 
 type Client interface {
+	ContextClient
+
+	// Close closes the client, the later calls fail with ierror.ClientShutdown.
+	io.Closer
+
+	// ServerVersion returns the version of the server detected when logging in, it is unknown before.
+	// The purge, segment deletion, flush and snapshot commands rejected by an older server fail with
+	// an UnsupportedCommandError naming this version.
+	ServerVersion() ServerVersion
+
 	// GetStream get the info about a specific stream by unique ID or name.
 	// Authentication is required, and the permission to read the streams.
 	GetStream(streamId Identifier) (*StreamDetails, error)
@@ -44,6 +55,10 @@ type Client interface {
 	// Authentication is required, and the permission to manage the topics.
 	DeleteStream(id Identifier) error
 
+	// PurgeStream delete all the messages of a stream by unique ID or name, keeping its topics and partitions.
+	// Authentication is required, and the permission to manage the streams.
+	PurgeStream(id Identifier) error
+
 	// GetTopic Get the info about a specific topic by unique ID or name.
 	// Authentication is required, and the permission to read the topics.
 	GetTopic(streamId, topicId Identifier) (*TopicDetails, error)
@@ -52,35 +67,34 @@ type Client interface {
 	// Authentication is required, and the permission to read the topics.
 	GetTopics(streamId Identifier) ([]Topic, error)
 
-	// CreateTopic create a new topic.
+	// CreateTopic create a new topic with at most MaxPartitionsCount partitions.
+	// The config is validated before the request is sent, and the topic ID is assigned by the server when nil.
 	// Authentication is required, and the permission to manage the topics.
 	CreateTopic(
 		streamId Identifier,
 		name string,
-		partitionsCount int,
-		compressionAlgorithm uint8,
-		messageExpiry time.Duration,
-		maxTopicSize uint64,
-		replicationFactor *uint8,
+		partitionsCount uint32,
+		config TopicConfig,
 		topicId *int,
 	) (*TopicDetails, error)
 
-	// UpdateTopic update a topic by unique ID or name.
+	// UpdateTopic update the name and the config of a topic by unique ID or name.
 	// Authentication is required, and the permission to manage the topics.
 	UpdateTopic(
 		streamId Identifier,
 		topicId Identifier,
 		name string,
-		compressionAlgorithm uint8,
-		messageExpiry time.Duration,
-		maxTopicSize uint64,
-		replicationFactor *uint8,
+		config TopicConfig,
 	) error
 
 	// DeleteTopic delete a topic by unique ID or name.
 	// Authentication is required, and the permission to manage the topics.
 	DeleteTopic(streamId, topicId Identifier) error
 
+	// PurgeTopic delete all the messages of a topic by unique ID or name, keeping its partitions and consumer groups.
+	// Authentication is required, and the permission to manage the topics.
+	PurgeTopic(streamId, topicId Identifier) error
+
 	// SendMessages sends messages using specified partitioning strategy to the given stream and topic by unique IDs or names.
 	// Authentication is required, and the permission to send the messages.
 	SendMessages(
@@ -90,6 +104,15 @@ type Client interface {
 		messages []IggyMessage,
 	) error
 
+	// FlushUnsavedBuffer force the server to save the buffered messages of a partition to disk, and to fsync them if requested.
+	// Authentication is required, and the permission to send the messages.
+	FlushUnsavedBuffer(
+		streamId Identifier,
+		topicId Identifier,
+		partitionId uint32,
+		fsync bool,
+	) error
+
 	// PollMessages poll given amount of messages using the specified consumer and strategy from the specified stream and topic by unique IDs or names.
 	// Authentication is required, and the permission to poll the messages.
 	PollMessages(
@@ -121,6 +144,16 @@ type Client interface {
 		partitionId *uint32,
 	) (*ConsumerOffsetInfo, error)
 
+	// DeleteConsumerOffset delete the consumer offset for a specific consumer or consumer group for the given stream and topic by unique IDs or names,
+	// so that the consumer has no committed offset anymore.
+	// Authentication is required, and the permission to poll the messages.
+	DeleteConsumerOffset(
+		consumer Consumer,
+		streamId Identifier,
+		topicId Identifier,
+		partitionId *uint32,
+	) error
+
 	// GetConsumerGroups get the info about all the consumer groups for the given stream and topic by unique IDs or names.
 	// Authentication is required, and the permission to read the streams or topics.
 	GetConsumerGroups(streamId Identifier, topicId Identifier) ([]ConsumerGroup, error)
@@ -184,6 +217,16 @@ type Client interface {
 		partitionsCount uint32,
 	) error
 
+	// DeleteSegments delete the oldest N segments of a partition by unique stream and topic IDs or names.
+	// Only the closed segments are deleted, the active segment of the partition is always kept.
+	// Authentication is required, and the permission to manage the topics.
+	DeleteSegments(
+		streamId Identifier,
+		topicId Identifier,
+		partitionId uint32,
+		segmentsCount uint32,
+	) error
+
 	// GetUser get the info about a specific user by unique ID or username.
 	// Authentication is required, and the permission to read the users, unless the provided user ID is the same as the authenticated user.
 	GetUser(identifier Identifier) (*UserInfoDetails, error)
@@ -247,6 +290,11 @@ type Client interface {
 	// Authentication is required, and the permission to read the server info.
 	GetStats() (*Stats, error)
 
+	// GetSnapshot get a zip archive of the diagnostic data of the server, such as its logs and resource usage.
+	// The archive is fully buffered in memory before it is returned, it can then be written to a file with Snapshot.WriteTo.
+	// Authentication is required.
+	GetSnapshot(compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error)
+
 	// Ping the server to check if it's alive.
 	Ping() error
 
@@ -257,4 +305,172 @@ type Client interface {
 	// GetClient get the info about a specific client by unique ID (not to be confused with the user).
 	// Authentication is required, and the permission to read the server info.
 	GetClient(clientId int) (*ClientInfoDetails, error)
+
+	// GetMe get the info about the current client, including the consumer groups it joined.
+	// Authentication is required.
+	GetMe() (*ClientInfoDetails, error)
+}
+
+// ContextClient mirrors Client with methods taking a context.Context as the first argument.
+// The context bounds the whole call: its deadline applies to the underlying reads and writes,
+// and cancelling it interrupts the request. An interrupted TCP request closes the connection,
+// which is opened again by the next call.
+type ContextClient interface {
+	GetStreamCtx(ctx context.Context, streamId Identifier) (*StreamDetails, error)
+	GetStreamsCtx(ctx context.Context) ([]Stream, error)
+	CreateStreamCtx(ctx context.Context, name string, streamId *uint32) (*StreamDetails, error)
+	UpdateStreamCtx(ctx context.Context, streamId Identifier, name string) error
+	DeleteStreamCtx(ctx context.Context, id Identifier) error
+	PurgeStreamCtx(ctx context.Context, id Identifier) error
+	GetTopicCtx(ctx context.Context, streamId, topicId Identifier) (*TopicDetails, error)
+	GetTopicsCtx(ctx context.Context, streamId Identifier) ([]Topic, error)
+	CreateTopicCtx(
+		ctx context.Context,
+		streamId Identifier,
+		name string,
+		partitionsCount uint32,
+		config TopicConfig,
+		topicId *int,
+	) (*TopicDetails, error)
+	UpdateTopicCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		name string,
+		config TopicConfig,
+	) error
+	DeleteTopicCtx(ctx context.Context, streamId, topicId Identifier) error
+	PurgeTopicCtx(ctx context.Context, streamId, topicId Identifier) error
+	SendMessagesCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		partitioning Partitioning,
+		messages []IggyMessage,
+	) error
+	FlushUnsavedBufferCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		partitionId uint32,
+		fsync bool,
+	) error
+	PollMessagesCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		consumer Consumer,
+		strategy PollingStrategy,
+		count uint32,
+		autoCommit bool,
+		partitionId *uint32,
+	) (*PolledMessage, error)
+	StoreConsumerOffsetCtx(
+		ctx context.Context,
+		consumer Consumer,
+		streamId Identifier,
+		topicId Identifier,
+		offset uint64,
+		partitionId *uint32,
+	) error
+	GetConsumerOffsetCtx(
+		ctx context.Context,
+		consumer Consumer,
+		streamId Identifier,
+		topicId Identifier,
+		partitionId *uint32,
+	) (*ConsumerOffsetInfo, error)
+	DeleteConsumerOffsetCtx(
+		ctx context.Context,
+		consumer Consumer,
+		streamId Identifier,
+		topicId Identifier,
+		partitionId *uint32,
+	) error
+	GetConsumerGroupsCtx(ctx context.Context, streamId Identifier, topicId Identifier) ([]ConsumerGroup, error)
+	GetConsumerGroupCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		groupId Identifier,
+	) (*ConsumerGroupDetails, error)
+	CreateConsumerGroupCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		name string,
+		groupId *uint32,
+	) (*ConsumerGroupDetails, error)
+	DeleteConsumerGroupCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		groupId Identifier,
+	) error
+	JoinConsumerGroupCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		groupId Identifier,
+	) error
+	LeaveConsumerGroupCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		groupId Identifier,
+	) error
+	CreatePartitionsCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		partitionsCount uint32,
+	) error
+	DeletePartitionsCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		partitionsCount uint32,
+	) error
+	DeleteSegmentsCtx(
+		ctx context.Context,
+		streamId Identifier,
+		topicId Identifier,
+		partitionId uint32,
+		segmentsCount uint32,
+	) error
+	GetUserCtx(ctx context.Context, identifier Identifier) (*UserInfoDetails, error)
+	GetUsersCtx(ctx context.Context) ([]UserInfo, error)
+	CreateUserCtx(
+		ctx context.Context,
+		username string,
+		password string,
+		status UserStatus,
+		permissions *Permissions,
+	) (*UserInfoDetails, error)
+	UpdateUserCtx(
+		ctx context.Context,
+		userID Identifier,
+		username *string,
+		status *UserStatus,
+	) error
+	UpdatePermissionsCtx(ctx context.Context, userID Identifier, permissions *Permissions) error
+	ChangePasswordCtx(
+		ctx context.Context,
+		userID Identifier,
+		currentPassword string,
+		newPassword string,
+	) error
+	DeleteUserCtx(ctx context.Context, identifier Identifier) error
+	CreatePersonalAccessTokenCtx(ctx context.Context, name string, expiry uint32) (*RawPersonalAccessToken, error)
+	DeletePersonalAccessTokenCtx(ctx context.Context, name string) error
+	GetPersonalAccessTokensCtx(ctx context.Context) ([]PersonalAccessTokenInfo, error)
+	LoginWithPersonalAccessTokenCtx(ctx context.Context, token string) (*IdentityInfo, error)
+	LoginUserCtx(ctx context.Context, username string, password string) (*IdentityInfo, error)
+	LogoutUserCtx(ctx context.Context) error
+	GetStatsCtx(ctx context.Context) (*Stats, error)
+	GetSnapshotCtx(ctx context.Context, compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error)
+	PingCtx(ctx context.Context) error
+	GetClientsCtx(ctx context.Context) ([]ClientInfo, error)
+	GetClientCtx(ctx context.Context, clientId int) (*ClientInfoDetails, error)
+	GetMeCtx(ctx context.Context) (*ClientInfoDetails, error)
 }
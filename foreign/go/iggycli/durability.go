@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggycli
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// Durability sets how far the sent messages are saved by the server when SendMessages returns.
+type Durability int
+
+const (
+	// DurabilityBuffered returns once the server has accepted the messages, which it saves to disk in the background.
+	DurabilityBuffered Durability = iota
+	// DurabilityFlushed also flushes the unsaved buffer of the partitions which received the messages.
+	DurabilityFlushed
+	// DurabilityFsynced also flushes and fsyncs the partitions which received the messages.
+	DurabilityFsynced
+)
+
+// WithDurability flushes the partitions after each successful SendMessages according to the durability.
+// The messages not sent to a specific partition flush every partition of the topic, as the server picks
+// their partitions. A failed flush returns an error although the messages were sent.
+func WithDurability(durability Durability) Option {
+	return func(opts *Options) {
+		opts.durability = durability
+	}
+}
+
+// durableClient flushes the unsaved buffer of the partitions after sending the messages.
+type durableClient struct {
+	Client
+	fsync bool
+}
+
+func (c *durableClient) SendMessages(streamId Identifier, topicId Identifier, partitioning Partitioning, messages []IggyMessage) error {
+	if err := c.Client.SendMessages(streamId, topicId, partitioning, messages); err != nil {
+		return err
+	}
+	return c.flush(streamId, topicId, partitioning, c.Client.GetTopic, c.Client.FlushUnsavedBuffer)
+}
+
+func (c *durableClient) SendMessagesCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitioning Partitioning, messages []IggyMessage) error {
+	if err := c.Client.SendMessagesCtx(ctx, streamId, topicId, partitioning, messages); err != nil {
+		return err
+	}
+	getTopic := func(streamId, topicId Identifier) (*TopicDetails, error) {
+		return c.Client.GetTopicCtx(ctx, streamId, topicId)
+	}
+	flushUnsavedBuffer := func(streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error {
+		return c.Client.FlushUnsavedBufferCtx(ctx, streamId, topicId, partitionId, fsync)
+	}
+	return c.flush(streamId, topicId, partitioning, getTopic, flushUnsavedBuffer)
+}
+
+func (c *durableClient) flush(
+	streamId Identifier,
+	topicId Identifier,
+	partitioning Partitioning,
+	getTopic func(streamId, topicId Identifier) (*TopicDetails, error),
+	flushUnsavedBuffer func(streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error,
+) error {
+	var partitionIds []uint32
+	if partitioning.Kind == PartitionIdKind && len(partitioning.Value) == 4 {
+		partitionIds = append(partitionIds, binary.LittleEndian.Uint32(partitioning.Value))
+	} else {
+		topic, err := getTopic(streamId, topicId)
+		if err != nil {
+			return fmt.Errorf("failed to flush the sent messages: %w", err)
+		}
+		for _, partition := range topic.Partitions {
+			partitionIds = append(partitionIds, uint32(partition.Id))
+		}
+	}
+
+	for _, partitionId := range partitionIds {
+		if err := flushUnsavedBuffer(streamId, topicId, partitionId, c.fsync); err != nil {
+			return fmt.Errorf("failed to flush the sent messages of partition %d: %w", partitionId, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggycli
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// flushRecorder records the flushed partitions, the other methods of Client are not implemented.
+type flushRecorder struct {
+	Client
+	topic   TopicDetails
+	flushed []uint32
+	fsync   bool
+	failing bool
+}
+
+func (r *flushRecorder) SendMessages(Identifier, Identifier, Partitioning, []IggyMessage) error {
+	return nil
+}
+
+func (r *flushRecorder) GetTopic(Identifier, Identifier) (*TopicDetails, error) {
+	return &r.topic, nil
+}
+
+func (r *flushRecorder) FlushUnsavedBuffer(_ Identifier, _ Identifier, partitionId uint32, fsync bool) error {
+	if r.failing {
+		return errors.New("flush failed")
+	}
+	r.flushed = append(r.flushed, partitionId)
+	r.fsync = fsync
+	return nil
+}
+
+func TestDurableClient_FlushesSentPartitions(t *testing.T) {
+	recorder := &flushRecorder{topic: TopicDetails{Partitions: []PartitionContract{{Id: 1}, {Id: 2}, {Id: 3}}}}
+	client := &durableClient{Client: recorder, fsync: true}
+	streamId, topicId := NewIdentifier(1), NewIdentifier(1)
+
+	if err := client.SendMessages(streamId, topicId, PartitionId(2), nil); err != nil {
+		t.Fatalf("failed to send the messages: %v", err)
+	}
+	if !slices.Equal(recorder.flushed, []uint32{2}) || !recorder.fsync {
+		t.Fatalf("expected partition 2 to be fsynced, got %v (fsync: %v)", recorder.flushed, recorder.fsync)
+	}
+
+	recorder.flushed = nil
+	if err := client.SendMessages(streamId, topicId, None(), nil); err != nil {
+		t.Fatalf("failed to send the messages: %v", err)
+	}
+	if !slices.Equal(recorder.flushed, []uint32{1, 2, 3}) {
+		t.Fatalf("expected every partition to be flushed, got %v", recorder.flushed)
+	}
+
+	recorder.failing = true
+	if err := client.SendMessages(streamId, topicId, PartitionId(1), nil); err == nil {
+		t.Fatal("expected the failed flush to be reported")
+	}
+}
@@ -19,20 +19,28 @@ package iggycli
 
 import (
 	"fmt"
+	"slices"
 
 	. "github.com/apache/iggy/foreign/go/contracts"
+	ihttp "github.com/apache/iggy/foreign/go/http"
 	"github.com/apache/iggy/foreign/go/tcp"
 )
 
 type Options struct {
-	protocol   Protocol
-	tcpOptions []tcp.Option
+	protocol     Protocol
+	tcpPool      bool
+	tcpOptions   []tcp.Option
+	httpOptions  []ihttp.Option
+	interceptors []CommandInterceptor
+	durability   Durability
 }
 
 func GetDefaultOptions() Options {
 	return Options{
-		protocol:   Tcp,
-		tcpOptions: nil,
+		protocol:    Tcp,
+		tcpPool:     false,
+		tcpOptions:  nil,
+		httpOptions: nil,
 	}
 }
 
@@ -42,10 +50,42 @@ type Option func(*Options)
 func WithTcp(tcpOpts ...tcp.Option) Option {
 	return func(opts *Options) {
 		opts.protocol = Tcp
+		opts.tcpPool = false
 		opts.tcpOptions = tcpOpts
 	}
 }
 
+// WithTcpPool sets the client protocol to TCP with a pool of connections and applies custom TCP options.
+// The pool size and the connection selection are set with tcp.WithPoolSize and tcp.WithPoolSelection.
+func WithTcpPool(tcpOpts ...tcp.Option) Option {
+	return func(opts *Options) {
+		opts.protocol = Tcp
+		opts.tcpPool = true
+		opts.tcpOptions = tcpOpts
+	}
+}
+
+// WithHttp sets the client protocol to HTTP and applies custom HTTP options.
+func WithHttp(httpOpts ...ihttp.Option) Option {
+	return func(opts *Options) {
+		opts.protocol = Http
+		opts.httpOptions = httpOpts
+	}
+}
+
+// WithInterceptors wraps every command sent by the client with the interceptors, whatever the protocol.
+func WithInterceptors(interceptors ...CommandInterceptor) Option {
+	return func(opts *Options) {
+		opts.interceptors = append(opts.interceptors, interceptors...)
+	}
+}
+
+// WithRetryPolicy retries the commands failing with a transient error, whatever the protocol.
+// It is added to the interceptors, so the interceptors set before it see a single call per command.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return WithInterceptors(RetryCommands(policy))
+}
+
 // NewIggyClient create the IggyClient instance.
 // If no Option is provided, NewIggyClient will create a default TCP client.
 func NewIggyClient(options ...Option) (Client, error) {
@@ -59,13 +99,23 @@ func NewIggyClient(options ...Option) (Client, error) {
 	var cli Client
 	switch opts.protocol {
 	case Tcp:
-		cli, err = tcp.NewIggyTcpClient(opts.tcpOptions...)
+		tcpOptions := append(slices.Clip(opts.tcpOptions), tcp.WithInterceptors(opts.interceptors...))
+		if opts.tcpPool {
+			cli, err = tcp.NewIggyTcpPool(tcpOptions...)
+		} else {
+			cli, err = tcp.NewIggyTcpClient(tcpOptions...)
+		}
+	case Http:
+		cli, err = ihttp.NewIggyHttpClient(append(slices.Clip(opts.httpOptions), ihttp.WithInterceptors(opts.interceptors...))...)
 	default:
 		return nil, fmt.Errorf("unknown protocol type: %v", opts.protocol)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create an iggy client: %w", err)
 	}
+	if opts.durability != DurabilityBuffered {
+		cli = &durableClient{Client: cli, fsync: opts.durability == DurabilityFsynced}
+	}
 
 	return cli, nil
 }
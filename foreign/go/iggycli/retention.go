@@ -0,0 +1,59 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggycli
+
+import (
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// TrimPartitions deletes the oldest segments of every partition of the topic larger than maxPartitionSize bytes,
+// and returns the number of segments requested for deletion.
+// The server does not expose the size of each segment, so the number of segments to delete is estimated
+// from the average segment size of the partition. The active segment is never deleted, so a partition
+// made of a single large segment is left as is until the segment is closed.
+func TrimPartitions(client Client, streamId, topicId Identifier, maxPartitionSize uint64) (uint32, error) {
+	topic, err := client.GetTopic(streamId, topicId)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted uint32
+	for _, partition := range topic.Partitions {
+		count := segmentsToTrim(partition, maxPartitionSize)
+		if count == 0 {
+			continue
+		}
+		if err := client.DeleteSegments(streamId, topicId, uint32(partition.Id), count); err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+	return deleted, nil
+}
+
+// segmentsToTrim returns the number of the oldest segments to delete for the partition to fit in maxPartitionSize bytes.
+func segmentsToTrim(partition PartitionContract, maxPartitionSize uint64) uint32 {
+	if partition.SegmentsCount <= 1 || partition.SizeBytes <= maxPartitionSize {
+		return 0
+	}
+
+	segments := uint64(partition.SegmentsCount)
+	segmentSize := (partition.SizeBytes + segments - 1) / segments
+	count := (partition.SizeBytes - maxPartitionSize + segmentSize - 1) / segmentSize
+	return uint32(min(count, segments-1))
+}
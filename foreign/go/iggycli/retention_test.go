@@ -0,0 +1,50 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iggycli
+
+import (
+	"testing"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func TestSegmentsToTrim(t *testing.T) {
+	tests := []struct {
+		name             string
+		segmentsCount    int
+		sizeBytes        uint64
+		maxPartitionSize uint64
+		expected         uint32
+	}{
+		{"below the target", 4, 400, 1000, 0},
+		{"at the target", 4, 400, 400, 0},
+		{"single segment", 1, 4000, 100, 0},
+		{"one segment over", 4, 400, 350, 1},
+		{"several segments over", 10, 1000, 450, 6},
+		{"keeps the active segment", 4, 400, 0, 3},
+		{"rounds the segment size up", 3, 1000, 600, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			partition := PartitionContract{SegmentsCount: test.segmentsCount, SizeBytes: test.sizeBytes}
+			if count := segmentsToTrim(partition, test.maxPartitionSize); count != test.expected {
+				t.Errorf("expected %d segments to trim, got %d", test.expected, count)
+			}
+		})
+	}
+}
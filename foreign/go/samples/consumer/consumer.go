@@ -47,6 +47,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	defer cli.Close()
 	_, err = cli.LoginUser("iggy", "iggy")
 	if err != nil {
 		panic("COULD NOT LOG IN")
@@ -81,10 +82,7 @@ func EnsureInfrastructureIsInitialized(cli iggycli.Client) error {
 			NewIdentifier(DefaultStreamId),
 			"Test Topic From Producer Sample",
 			12,
-			0,
-			0,
-			0,
-			nil,
+			TopicConfig{},
 			&uint32TopicId)
 
 		if topicErr != nil {
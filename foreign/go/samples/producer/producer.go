@@ -46,6 +46,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	defer cli.Close()
 	_, err = cli.LoginUser("iggy", "iggy")
 	if err != nil {
 		panic("COULD NOT LOG IN")
@@ -82,10 +83,7 @@ func EnsureInfrastructureIsInitialized(cli iggycli.Client) error {
 			NewIdentifier(StreamId),
 			"Test Topic From Producer Sample",
 			12,
-			0,
-			0,
-			0,
-			nil,
+			TopicConfig{},
 			&refStreamId)
 
 		if topicErr != nil {
@@ -18,16 +18,22 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 )
 
 func (tms *IggyTcpClient) CreatePersonalAccessToken(name string, expiry uint32) (*RawPersonalAccessToken, error) {
+	return tms.CreatePersonalAccessTokenCtx(tms.ctx, name, expiry)
+}
+
+func (tms *IggyTcpClient) CreatePersonalAccessTokenCtx(ctx context.Context, name string, expiry uint32) (*RawPersonalAccessToken, error) {
 	message := binaryserialization.SerializeCreatePersonalAccessToken(CreatePersonalAccessTokenRequest{
 		Name:   name,
 		Expiry: expiry,
 	})
-	buffer, err := tms.sendAndFetchResponse(message, CreateAccessTokenCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, CreateAccessTokenCode)
 	if err != nil {
 		return nil, err
 	}
@@ -36,15 +42,23 @@ func (tms *IggyTcpClient) CreatePersonalAccessToken(name string, expiry uint32)
 }
 
 func (tms *IggyTcpClient) DeletePersonalAccessToken(name string) error {
+	return tms.DeletePersonalAccessTokenCtx(tms.ctx, name)
+}
+
+func (tms *IggyTcpClient) DeletePersonalAccessTokenCtx(ctx context.Context, name string) error {
 	message := binaryserialization.SerializeDeletePersonalAccessToken(DeletePersonalAccessTokenRequest{
 		Name: name,
 	})
-	_, err := tms.sendAndFetchResponse(message, DeleteAccessTokenCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, DeleteAccessTokenCode)
 	return err
 }
 
 func (tms *IggyTcpClient) GetPersonalAccessTokens() ([]PersonalAccessTokenInfo, error) {
-	buffer, err := tms.sendAndFetchResponse([]byte{}, GetAccessTokensCode)
+	return tms.GetPersonalAccessTokensCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) GetPersonalAccessTokensCtx(ctx context.Context) ([]PersonalAccessTokenInfo, error) {
+	buffer, err := tms.sendAndFetchResponse(ctx, []byte{}, GetAccessTokensCode)
 	if err != nil {
 		return nil, err
 	}
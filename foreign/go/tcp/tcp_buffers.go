@@ -0,0 +1,125 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"net"
+	"sync"
+
+	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+const (
+	// minBufferClass and maxBufferClass bound the sizes of the pooled buffers, from 1 KiB to 64 MiB.
+	minBufferClass = 10
+	maxBufferClass = 26
+)
+
+// bufferPools holds the reusable buffers by size class, the pool i holding buffers of 1 << (minBufferClass + i) bytes.
+var bufferPools [maxBufferClass - minBufferClass + 1]sync.Pool
+
+func bufferClass(size int) int {
+	if size <= 1<<minBufferClass {
+		return minBufferClass
+	}
+	return bits.Len(uint(size - 1))
+}
+
+// getBuffer returns a buffer of the given size, reused from the pool of its size class when possible.
+func getBuffer(size int) *[]byte {
+	class := bufferClass(size)
+	if class > maxBufferClass {
+		buffer := make([]byte, size)
+		return &buffer
+	}
+	if pooled, ok := bufferPools[class-minBufferClass].Get().(*[]byte); ok {
+		*pooled = (*pooled)[:size]
+		return pooled
+	}
+	buffer := make([]byte, size, 1<<class)
+	return &buffer
+}
+
+// putBuffer returns a buffer obtained from getBuffer to its pool, it must not be used afterwards.
+func putBuffer(buffer *[]byte) {
+	class := bufferClass(cap(*buffer))
+	if class > maxBufferClass || cap(*buffer) != 1<<class {
+		return
+	}
+	bufferPools[class-minBufferClass].Put(buffer)
+}
+
+// frameHeaderSize is the size of the length and the command code preceding each request.
+const frameHeaderSize = 8
+
+// frame is a request ready to be written, split into buffers for a vectored write.
+type frame struct {
+	buffers net.Buffers
+	pooled  *[]byte
+}
+
+// newFrame copies the message after the frame header into a pooled buffer.
+func newFrame(message []byte, command CommandCode) *frame {
+	pooled := getBuffer(frameHeaderSize + len(message))
+	copy((*pooled)[frameHeaderSize:], message)
+	f := &frame{buffers: net.Buffers{*pooled}, pooled: pooled}
+	f.putHeader(command)
+	return f
+}
+
+// newSendMessagesFrame serializes the messages into a pooled buffer, except for the large payloads which are
+// written straight from the messages.
+func newSendMessagesFrame(request *binaryserialization.TcpSendMessagesRequest, compression IggyMessageCompression) *frame {
+	var pooled *[]byte
+	buffers := request.SerializeBuffers(compression, frameHeaderSize, func(size int) []byte {
+		pooled = getBuffer(size)
+		return *pooled
+	})
+	f := &frame{buffers: buffers, pooled: pooled}
+	f.putHeader(SendMessagesCode)
+	return f
+}
+
+// putHeader writes the frame header into the headroom left at the start of the first buffer.
+func (f *frame) putHeader(command CommandCode) {
+	length := 0
+	for _, buffer := range f.buffers {
+		length += len(buffer)
+	}
+	header := f.buffers[0]
+	binary.LittleEndian.PutUint32(header[0:4], uint32(length-InitialBytesLength))
+	binary.LittleEndian.PutUint32(header[4:8], uint32(command))
+}
+
+// writeTo writes the whole frame and releases its pooled buffer, the frame can be written only once.
+func (f *frame) writeTo(conn net.Conn) error {
+	_, err := f.buffers.WriteTo(conn)
+	f.release()
+	return err
+}
+
+// release returns the pooled buffer of the frame, which must not be written afterwards.
+func (f *frame) release() {
+	if f.pooled != nil {
+		putBuffer(f.pooled)
+		f.pooled = nil
+	}
+}
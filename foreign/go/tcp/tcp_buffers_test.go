@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func TestGetBuffer_SizeClasses(t *testing.T) {
+	tests := []struct {
+		size        int
+		expectedCap int
+	}{
+		{size: 0, expectedCap: 1 << minBufferClass},
+		{size: 1024, expectedCap: 1024},
+		{size: 1025, expectedCap: 2048},
+		{size: 1<<maxBufferClass + 1, expectedCap: 1<<maxBufferClass + 1},
+	}
+	for _, tt := range tests {
+		buffer := getBuffer(tt.size)
+		if len(*buffer) != tt.size || cap(*buffer) != tt.expectedCap {
+			t.Errorf("getBuffer(%d): expected len %d and cap %d, got len %d and cap %d", tt.size, tt.size, tt.expectedCap, len(*buffer), cap(*buffer))
+		}
+		putBuffer(buffer)
+	}
+}
+
+func TestSendMessages_WritesLargePayloads(t *testing.T) {
+	var mtx sync.Mutex
+	var received []byte
+	server := startTestServer(t, func(command iggcon.CommandCode, payload []byte) (uint32, []byte) {
+		if command == iggcon.SendMessagesCode {
+			mtx.Lock()
+			received = payload
+			mtx.Unlock()
+		}
+		return 0, nil
+	})
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	var messages []iggcon.IggyMessage
+	for _, payload := range []string{"small", strings.Repeat("large", binaryserialization.ZeroCopyPayloadSize)} {
+		message, err := iggcon.NewIggyMessage([]byte(payload))
+		if err != nil {
+			t.Fatalf("failed to create the message: %v", err)
+		}
+		messages = append(messages, message)
+	}
+	stream, topic, partitioning := iggcon.NewIdentifier(1), iggcon.NewIdentifier(2), iggcon.PartitionId(1)
+	if err := client.SendMessages(stream, topic, partitioning, messages); err != nil {
+		t.Fatalf("failed to send the messages: %v", err)
+	}
+
+	request := binaryserialization.TcpSendMessagesRequest{
+		StreamId:     stream,
+		TopicId:      topic,
+		Partitioning: partitioning,
+		Messages:     messages,
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	if expected := request.Serialize(iggcon.MESSAGE_COMPRESSION_NONE); !bytes.Equal(received, expected) {
+		t.Fatalf("expected the server to receive the serialized request of %d bytes, got %d bytes", len(expected), len(received))
+	}
+}
+
+// The streams of a response larger than 2^16 bytes are read over several reads of the socket.
+func TestGetStreams_ReadsLargeResponses(t *testing.T) {
+	const streamsCount = 1000
+	var response []byte
+	for id := uint32(1); id <= streamsCount; id++ {
+		name := strings.Repeat("s", 100) + strconv.Itoa(int(id))
+		response = binary.LittleEndian.AppendUint32(response, id)
+		response = binary.LittleEndian.AppendUint64(response, 1000)
+		response = binary.LittleEndian.AppendUint32(response, 0)
+		response = binary.LittleEndian.AppendUint64(response, 2048)
+		response = binary.LittleEndian.AppendUint64(response, 16)
+		response = append(response, byte(len(name)))
+		response = append(response, name...)
+	}
+	if len(response) <= 1<<16 {
+		t.Fatalf("expected a response larger than 2^16 bytes, got %d", len(response))
+	}
+	server := startTestServer(t, func(iggcon.CommandCode, []byte) (uint32, []byte) {
+		return 0, response
+	})
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	streams, err := client.GetStreams()
+	if err != nil {
+		t.Fatalf("failed to get the streams: %v", err)
+	}
+	if len(streams) != streamsCount {
+		t.Fatalf("expected %d streams, got %d", streamsCount, len(streams))
+	}
+	for i, stream := range streams {
+		if stream.Id != i+1 || !strings.HasSuffix(stream.Name, strconv.Itoa(i+1)) || stream.SizeBytes != 2048 {
+			t.Fatalf("unexpected stream at %d: %+v", i, stream)
+		}
+	}
+}
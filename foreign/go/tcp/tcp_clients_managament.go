@@ -18,12 +18,18 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 )
 
 func (tms *IggyTcpClient) GetClients() ([]ClientInfo, error) {
-	buffer, err := tms.sendAndFetchResponse([]byte{}, GetClientsCode)
+	return tms.GetClientsCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) GetClientsCtx(ctx context.Context) ([]ClientInfo, error) {
+	buffer, err := tms.sendAndFetchResponse(ctx, []byte{}, GetClientsCode)
 	if err != nil {
 		return nil, err
 	}
@@ -31,9 +37,26 @@ func (tms *IggyTcpClient) GetClients() ([]ClientInfo, error) {
 	return binaryserialization.DeserializeClients(buffer)
 }
 
+func (tms *IggyTcpClient) GetMe() (*ClientInfoDetails, error) {
+	return tms.GetMeCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) GetMeCtx(ctx context.Context) (*ClientInfoDetails, error) {
+	buffer, err := tms.sendAndFetchResponse(ctx, []byte{}, GetMeCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return binaryserialization.DeserializeClient(buffer), nil
+}
+
 func (tms *IggyTcpClient) GetClient(clientId int) (*ClientInfoDetails, error) {
+	return tms.GetClientCtx(tms.ctx, clientId)
+}
+
+func (tms *IggyTcpClient) GetClientCtx(ctx context.Context, clientId int) (*ClientInfoDetails, error) {
 	message := binaryserialization.SerializeInt(clientId)
-	buffer, err := tms.sendAndFetchResponse(message, GetClientCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetClientCode)
 	if err != nil {
 		return nil, err
 	}
@@ -18,24 +18,34 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
 func (tms *IggyTcpClient) GetConsumerGroups(streamId, topicId Identifier) ([]ConsumerGroup, error) {
+	return tms.GetConsumerGroupsCtx(tms.ctx, streamId, topicId)
+}
+
+func (tms *IggyTcpClient) GetConsumerGroupsCtx(ctx context.Context, streamId, topicId Identifier) ([]ConsumerGroup, error) {
 	message := binaryserialization.SerializeIdentifiers(streamId, topicId)
-	buffer, err := tms.sendAndFetchResponse(message, GetGroupsCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetGroupsCode)
 	if err != nil {
 		return nil, err
 	}
 
-	return binaryserialization.DeserializeConsumerGroups(buffer), err
+	return binaryserialization.DeserializeConsumerGroups(buffer)
 }
 
 func (tms *IggyTcpClient) GetConsumerGroup(streamId, topicId, groupId Identifier) (*ConsumerGroupDetails, error) {
+	return tms.GetConsumerGroupCtx(tms.ctx, streamId, topicId, groupId)
+}
+
+func (tms *IggyTcpClient) GetConsumerGroupCtx(ctx context.Context, streamId, topicId, groupId Identifier) (*ConsumerGroupDetails, error) {
 	message := binaryserialization.SerializeIdentifiers(streamId, topicId, groupId)
-	buffer, err := tms.sendAndFetchResponse(message, GetGroupCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetGroupCode)
 	if err != nil {
 		return nil, err
 	}
@@ -43,11 +53,14 @@ func (tms *IggyTcpClient) GetConsumerGroup(streamId, topicId, groupId Identifier
 		return nil, ierror.ConsumerGroupIdNotFound
 	}
 
-	consumerGroupDetails := binaryserialization.DeserializeConsumerGroup(buffer)
-	return consumerGroupDetails, err
+	return binaryserialization.DeserializeConsumerGroup(buffer)
 }
 
 func (tms *IggyTcpClient) CreateConsumerGroup(streamId Identifier, topicId Identifier, name string, groupId *uint32) (*ConsumerGroupDetails, error) {
+	return tms.CreateConsumerGroupCtx(tms.ctx, streamId, topicId, name, groupId)
+}
+
+func (tms *IggyTcpClient) CreateConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, name string, groupId *uint32) (*ConsumerGroupDetails, error) {
 	if MaxStringLength < len(name) {
 		return nil, ierror.TextTooLong("consumer_group_name")
 	}
@@ -57,28 +70,51 @@ func (tms *IggyTcpClient) CreateConsumerGroup(streamId Identifier, topicId Ident
 		ConsumerGroupId: groupId,
 		Name:            name,
 	})
-	buffer, err := tms.sendAndFetchResponse(message, CreateGroupCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, CreateGroupCode)
 	if err != nil {
 		return nil, err
 	}
-	consumerGroup := binaryserialization.DeserializeConsumerGroup(buffer)
-	return consumerGroup, err
+	return binaryserialization.DeserializeConsumerGroup(buffer)
 }
 
 func (tms *IggyTcpClient) DeleteConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return tms.DeleteConsumerGroupCtx(tms.ctx, streamId, topicId, groupId)
+}
+
+func (tms *IggyTcpClient) DeleteConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
 	message := binaryserialization.SerializeIdentifiers(streamId, topicId, groupId)
-	_, err := tms.sendAndFetchResponse(message, DeleteGroupCode)
-	return err
+	_, err := tms.sendAndFetchResponse(ctx, message, DeleteGroupCode)
+	if err != nil {
+		return err
+	}
+	tms.removeJoinedGroup(message)
+	return nil
 }
 
 func (tms *IggyTcpClient) JoinConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return tms.JoinConsumerGroupCtx(tms.ctx, streamId, topicId, groupId)
+}
+
+func (tms *IggyTcpClient) JoinConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
 	message := binaryserialization.SerializeIdentifiers(streamId, topicId, groupId)
-	_, err := tms.sendAndFetchResponse(message, JoinGroupCode)
-	return err
+	_, err := tms.sendAndFetchResponse(ctx, message, JoinGroupCode)
+	if err != nil {
+		return err
+	}
+	tms.addJoinedGroup(message)
+	return nil
 }
 
 func (tms *IggyTcpClient) LeaveConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return tms.LeaveConsumerGroupCtx(tms.ctx, streamId, topicId, groupId)
+}
+
+func (tms *IggyTcpClient) LeaveConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
 	message := binaryserialization.SerializeIdentifiers(streamId, topicId, groupId)
-	_, err := tms.sendAndFetchResponse(message, LeaveGroupCode)
-	return err
+	_, err := tms.sendAndFetchResponse(ctx, message, LeaveGroupCode)
+	if err != nil {
+		return err
+	}
+	tms.removeJoinedGroup(message)
+	return nil
 }
@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// blockingHandler holds the first request until released, and answers the following ones immediately.
+func blockingHandler() (commandHandler, <-chan struct{}, func()) {
+	received := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	handler := func(iggcon.CommandCode, []byte) (uint32, []byte) {
+		if calls.Add(1) == 1 {
+			close(received)
+			<-release
+		}
+		return 0, nil
+	}
+	return handler, received, sync.OnceFunc(func() { close(release) })
+}
+
+func TestContext_DeadlineInterruptsRequest(t *testing.T) {
+	handler, _, unblock := blockingHandler()
+	server := startTestServer(t, handler)
+	t.Cleanup(unblock)
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = client.PingCtx(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the request to be interrupted at its deadline, it took %v", elapsed)
+	}
+
+	// The interrupted request closed the connection, the next one opens a new one.
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the next request to succeed, got %v", err)
+	}
+}
+
+func TestContext_CancelInterruptsRequest(t *testing.T) {
+	handler, received, unblock := blockingHandler()
+	server := startTestServer(t, handler)
+	t.Cleanup(unblock)
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		result <- client.PingCtx(ctx)
+	}()
+	<-received
+
+	// A request waiting for the connection gives up at its own deadline.
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer waitCancel()
+	if err := client.PingCtx(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the waiting request to time out, got %v", err)
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the request to be cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the request was not interrupted by the cancellation")
+	}
+
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the next request to succeed, got %v", err)
+	}
+}
+
+func TestContext_CancelledBeforeRequest(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.PingCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the request to be cancelled, got %v", err)
+	}
+	if commands := recorder.received(); len(commands) != 0 {
+		t.Fatalf("expected no request to reach the server, got %v", commands)
+	}
+}
@@ -19,9 +19,14 @@ package tcp
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/binary"
+	"errors"
 	"net"
+	"os"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	. "github.com/apache/iggy/foreign/go/contracts"
@@ -34,7 +39,18 @@ type Option func(config *Options)
 type Options struct {
 	Ctx               context.Context
 	ServerAddress     string
+	ServerAddresses   []string
+	Failover          FailoverOptions
 	HeartbeatInterval time.Duration
+	OnHeartbeat       func(err error)
+	LogoutOnClose     bool
+	OnEvent           func(event Event)
+	TLS               TLSOptions
+	Reconnect         ReconnectOptions
+	Pool              PoolOptions
+	Pipeline          PipelineOptions
+	Checksums         ChecksumOptions
+	Interceptors      []iggcon.CommandInterceptor
 }
 
 func GetDefaultOptions() Options {
@@ -42,12 +58,49 @@ func GetDefaultOptions() Options {
 		Ctx:               context.Background(),
 		ServerAddress:     "127.0.0.1:8090",
 		HeartbeatInterval: time.Second * 5,
+		Reconnect: ReconnectOptions{
+			MaxRetries:  5,
+			Interval:    time.Second,
+			MaxInterval: time.Second * 30,
+		},
+		Pool: PoolOptions{
+			Size:      4,
+			Selection: LeastBusy,
+		},
+		Pipeline: PipelineOptions{
+			MaxInFlight: 256,
+		},
+		Failover: FailoverOptions{
+			Order:         Ordered,
+			ProbeInterval: time.Second * 5,
+			Failback:      true,
+		},
 	}
 }
 
 type IggyTcpClient struct {
-	conn               *net.TCPConn
-	mtx                sync.Mutex
+	ctx                context.Context
+	cancel             context.CancelFunc
+	closing            atomic.Bool
+	closed             atomic.Bool
+	healthy            atomic.Bool
+	connected          atomic.Bool
+	events             eventHub
+	onHeartbeat        func(err error)
+	logoutOnClose      bool
+	logoutTimeout      time.Duration
+	conn               net.Conn
+	connLock           chan struct{}
+	failover           *failover
+	reconnect          ReconnectOptions
+	pipelineOptions    PipelineOptions
+	pipeline           *pipeline
+	checksums          ChecksumOptions
+	sessionMtx         sync.Mutex
+	session            session
+	interceptors       []iggcon.CommandInterceptor
+	invoke             iggcon.CommandInvoker
+	serverVersion      atomic.Pointer[iggcon.ServerVersion]
 	MessageCompression iggcon.IggyMessageCompression
 }
 
@@ -55,6 +108,7 @@ type IggyTcpClient struct {
 func WithServerAddress(address string) Option {
 	return func(opts *Options) {
 		opts.ServerAddress = address
+		opts.ServerAddresses = nil
 	}
 }
 
@@ -65,6 +119,19 @@ func WithContext(ctx context.Context) Option {
 	}
 }
 
+// WithInterceptors appends interceptors wrapping every command, they see the binary request and response payloads.
+func WithInterceptors(interceptors ...iggcon.CommandInterceptor) Option {
+	return func(opts *Options) {
+		opts.Interceptors = append(opts.Interceptors, interceptors...)
+	}
+}
+
+// WithRetryPolicy retries the commands failing with a transient error, see iggcon.RetryPolicy.
+// It is added to the interceptors, so the interceptors set before it see a single call per command.
+func WithRetryPolicy(policy iggcon.RetryPolicy) Option {
+	return WithInterceptors(iggcon.RetryCommands(policy))
+}
+
 func NewIggyTcpClient(options ...Option) (*IggyTcpClient, error) {
 	opts := GetDefaultOptions()
 	for _, opt := range options {
@@ -72,55 +139,97 @@ func NewIggyTcpClient(options ...Option) (*IggyTcpClient, error) {
 			opt(&opts)
 		}
 	}
-	addr, err := net.ResolveTCPAddr("tcp", opts.ServerAddress)
-	if err != nil {
-		return nil, err
+	addresses := opts.ServerAddresses
+	if len(addresses) == 0 {
+		addresses = []string{opts.ServerAddress}
 	}
-	ctx := opts.Ctx
-	var d = net.Dialer{
-		KeepAlive: -1,
+	endpoints := make([]*endpoint, 0, len(addresses))
+	for _, address := range addresses {
+		dial, err := newDialer(address, opts.TLS)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, newEndpoint(address, dial))
 	}
-	conn, err := d.DialContext(ctx, "tcp", addr.String())
+	failover := &failover{options: opts.Failover, endpoints: endpoints}
+	conn, err := failover.dial(opts.Ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(opts.Ctx)
 	client := &IggyTcpClient{
-		conn: conn.(*net.TCPConn),
-	}
-
-	heartbeatInterval := opts.HeartbeatInterval
-	if heartbeatInterval > 0 {
-		go func() {
-			ticker := time.NewTicker(heartbeatInterval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					client.Ping()
-				}
-			}
-		}()
+		ctx:             ctx,
+		cancel:          cancel,
+		onHeartbeat:     opts.OnHeartbeat,
+		logoutOnClose:   opts.LogoutOnClose,
+		logoutTimeout:   logoutOnCloseTimeout,
+		connLock:        make(chan struct{}, 1),
+		failover:        failover,
+		reconnect:       opts.Reconnect,
+		pipelineOptions: opts.Pipeline,
+		checksums:       opts.Checksums,
+		interceptors:    opts.Interceptors,
+	}
+	interceptors := append(slices.Clip(opts.Interceptors), iggcon.RequireCapabilities(client.ServerVersion))
+	client.invoke = iggcon.ChainInterceptors(func(ctx context.Context, command iggcon.CommandCode, request []byte) ([]byte, error) {
+		return client.sendFrameAndFetchResponse(ctx, newFrame(request, command))
+	}, interceptors...)
+
+	client.events.handler = opts.OnEvent
+	client.setConnected(conn)
+	client.healthy.Store(true)
+	if opts.HeartbeatInterval > 0 {
+		go client.heartbeat(opts.HeartbeatInterval)
+	}
+	if len(endpoints) > 1 && opts.Failover.ProbeInterval > 0 {
+		go client.probeEndpoints(opts.Failover.ProbeInterval)
 	}
 
 	return client, nil
 }
 
+// newDialer returns the function opening a connection to the given address.
+func newDialer(address string, tlsOptions TLSOptions) (func(ctx context.Context) (net.Conn, error), error) {
+	addr, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	var d = net.Dialer{
+		KeepAlive: -1,
+	}
+	if !tlsOptions.Enabled {
+		return func(ctx context.Context) (net.Conn, error) {
+			return d.DialContext(ctx, "tcp", addr.String())
+		}, nil
+	}
+
+	tlsConfig, err := tlsOptions.config(address)
+	if err != nil {
+		return nil, err
+	}
+	tlsDialer := tls.Dialer{
+		NetDialer: &d,
+		Config:    tlsConfig,
+	}
+	return func(ctx context.Context) (net.Conn, error) {
+		return tlsDialer.DialContext(ctx, "tcp", addr.String())
+	}, nil
+}
+
 const (
 	InitialBytesLength   = 4
 	ExpectedResponseSize = 8
 	MaxStringLength      = 255
 )
 
-func (tms *IggyTcpClient) read(expectedSize int) (int, []byte, error) {
+func read(conn net.Conn, expectedSize int) (int, []byte, error) {
 	var totalRead int
 	buffer := make([]byte, expectedSize)
 
 	for totalRead < expectedSize {
 		readSize := expectedSize - totalRead
-		n, err := tms.conn.Read(buffer[totalRead : totalRead+readSize])
+		n, err := conn.Read(buffer[totalRead : totalRead+readSize])
 		if err != nil {
 			return totalRead, buffer[:totalRead], err
 		}
@@ -130,29 +239,125 @@ func (tms *IggyTcpClient) read(expectedSize int) (int, []byte, error) {
 	return totalRead, buffer, nil
 }
 
-func (tms *IggyTcpClient) write(payload []byte) (int, error) {
-	var totalWritten int
-	for totalWritten < len(payload) {
-		n, err := tms.conn.Write(payload[totalWritten:])
-		if err != nil {
-			return totalWritten, err
+func (tms *IggyTcpClient) sendAndFetchResponse(ctx context.Context, message []byte, command CommandCode) ([]byte, error) {
+	return tms.invoke(ctx, command, message)
+}
+
+func (tms *IggyTcpClient) sendFrameAndFetchResponse(ctx context.Context, request *frame) ([]byte, error) {
+	if tms.closed.Load() {
+		return nil, ierror.ClientShutdown
+	}
+
+	// Closing the client interrupts the requests in flight.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(tms.ctx, cancel)
+	defer stop()
+
+	buffer, err := tms.exchange(ctx, request)
+	if err != nil && tms.closed.Load() {
+		return nil, ierror.ClientShutdown
+	}
+
+	return buffer, err
+}
+
+func (tms *IggyTcpClient) exchange(ctx context.Context, request *frame) ([]byte, error) {
+	if tms.pipelineOptions.Enabled {
+		return tms.sendPipelined(ctx, request)
+	}
+
+	if err := tms.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer tms.unlock()
+
+	if tms.conn == nil {
+		if err := tms.restoreConnection(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer, err := tms.roundTrip(ctx, request)
+	if err != nil && isConnectionError(err) && !isContextError(err) {
+		tms.failover.markFailed()
+		tms.disconnected(err)
+		// The request is not retried, as it might have reached the server already.
+		if tms.reconnect.Enabled {
+			_ = tms.restoreConnection(ctx)
+		} else if tms.failover.multiple() {
+			// The next request moves to another endpoint.
+			tms.closeConnection()
 		}
-		totalWritten += n
 	}
 
-	return totalWritten, nil
+	if err != nil && isUnauthenticated(err) && tms.loggedIn() {
+		tms.emit(LoggedOut, err)
+	}
+
+	return buffer, err
 }
 
-func (tms *IggyTcpClient) sendAndFetchResponse(message []byte, command CommandCode) ([]byte, error) {
-	tms.mtx.Lock()
-	defer tms.mtx.Unlock()
+// lock acquires the connection, giving up when the context is done first.
+// connLock is a channel rather than a mutex so that waiting for it can be cancelled.
+func (tms *IggyTcpClient) lock(ctx context.Context) error {
+	select {
+	case tms.connLock <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (tms *IggyTcpClient) unlock() {
+	<-tms.connLock
+}
+
+// roundTrip writes the request and reads its response, the caller must hold the connection lock.
+// The context deadline applies to the socket reads and writes, and a cancelled context interrupts them.
+// An interrupted request leaves the rest of its response on the wire, so the connection is closed
+// and the next request opens a new one.
+func (tms *IggyTcpClient) roundTrip(ctx context.Context, request *frame) (_ []byte, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	conn := tms.conn
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+	interrupted := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+		close(interrupted)
+	})
+	defer func() {
+		if !stop() {
+			<-interrupted
+		}
+		if err != nil && isConnectionError(err) && (ctx.Err() != nil || errors.Is(err, os.ErrDeadlineExceeded)) {
+			if err = ctx.Err(); err == nil {
+				err = context.DeadlineExceeded
+			}
+			tms.disconnected(err)
+			tms.closeConnection()
+			return
+		}
+		_ = conn.SetDeadline(time.Time{})
+	}()
 
-	payload := createPayload(message, command)
-	if _, err := tms.write(payload); err != nil {
+	if err := request.writeTo(conn); err != nil {
 		return nil, err
 	}
 
-	_, buffer, err := tms.read(ExpectedResponseSize)
+	return readResponse(conn)
+}
+
+// readResponse reads the response of the oldest request written to the connection.
+func readResponse(conn net.Conn) ([]byte, error) {
+	_, buffer, err := read(conn, ExpectedResponseSize)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +386,7 @@ func (tms *IggyTcpClient) sendAndFetchResponse(message []byte, command CommandCo
 		return []byte{}, nil
 	}
 
-	_, buffer, err = tms.read(length)
+	_, buffer, err = read(conn, length)
 	if err != nil {
 		return nil, err
 	}
@@ -189,15 +394,6 @@ func (tms *IggyTcpClient) sendAndFetchResponse(message []byte, command CommandCo
 	return buffer, nil
 }
 
-func createPayload(message []byte, command CommandCode) []byte {
-	messageLength := len(message) + 4
-	messageBytes := make([]byte, InitialBytesLength+messageLength)
-	binary.LittleEndian.PutUint32(messageBytes[:4], uint32(messageLength))
-	binary.LittleEndian.PutUint32(messageBytes[4:8], uint32(command))
-	copy(messageBytes[8:], message)
-	return messageBytes
-}
-
 func getResponseCode(buffer []byte) int {
 	return int(binary.LittleEndian.Uint32(buffer[:4]))
 }
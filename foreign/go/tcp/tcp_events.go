@@ -0,0 +1,167 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// EventKind is the kind of change reported by an Event.
+type EventKind int
+
+const (
+	// Connected is emitted when a connection to the server is opened.
+	Connected EventKind = iota + 1
+	// Disconnected is emitted when the connection breaks or is closed, with the error which broke it.
+	Disconnected
+	// Reconnecting is emitted before each attempt to open the connection again, with the error of the previous attempt.
+	Reconnecting
+	// Authenticated is emitted when the user logs in, including when the session is restored on a new connection.
+	Authenticated
+	// LoggedOut is emitted when the user logs out, or with the error when the server no longer accepts the session.
+	LoggedOut
+	// HeartbeatFailed is emitted with the error of a failed heartbeat.
+	HeartbeatFailed
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case Connected:
+		return "connected"
+	case Disconnected:
+		return "disconnected"
+	case Reconnecting:
+		return "reconnecting"
+	case Authenticated:
+		return "authenticated"
+	case LoggedOut:
+		return "logged_out"
+	case HeartbeatFailed:
+		return "heartbeat_failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a change of the connection or of the session of the client.
+type Event struct {
+	Kind EventKind
+	Time time.Time
+	// Address is the server address of the connection the event relates to.
+	Address string
+	Err     error
+}
+
+// WithEventHandler sets the function called with every event of the client.
+// It is called synchronously while the connection is in use, so it must return quickly and must not call the client.
+func WithEventHandler(handler func(event Event)) Option {
+	return func(opts *Options) {
+		opts.OnEvent = handler
+	}
+}
+
+// SubscribeEvents returns a channel receiving the events of the client, and the function ending the subscription.
+// The events are dropped when the channel buffer is full rather than slowing the client down.
+// The channel is closed when the subscription ends or the client is closed.
+func (tms *IggyTcpClient) SubscribeEvents(bufferSize int) (<-chan Event, func()) {
+	return tms.events.subscribe(bufferSize)
+}
+
+// eventHub dispatches the events to the handler and to the subscribers.
+type eventHub struct {
+	handler     func(event Event)
+	mtx         sync.Mutex
+	subscribers map[chan Event]struct{}
+	closed      bool
+}
+
+func (h *eventHub) subscribe(bufferSize int) (<-chan Event, func()) {
+	events := make(chan Event, bufferSize)
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.closed {
+		close(events)
+		return events, func() {}
+	}
+	if h.subscribers == nil {
+		h.subscribers = make(map[chan Event]struct{})
+	}
+	h.subscribers[events] = struct{}{}
+
+	return events, func() {
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		if _, ok := h.subscribers[events]; ok {
+			delete(h.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+func (h *eventHub) publish(event Event) {
+	if h.handler != nil {
+		h.handler(event)
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for events := range h.subscribers {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// close ends all the subscriptions.
+func (h *eventHub) close() {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.closed = true
+	for events := range h.subscribers {
+		close(events)
+	}
+	h.subscribers = nil
+}
+
+func (tms *IggyTcpClient) emit(kind EventKind, err error) {
+	event := Event{
+		Kind: kind,
+		Time: time.Now(),
+		Err:  err,
+	}
+	if endpoint := tms.failover.current.Load(); endpoint != nil {
+		event.Address = endpoint.address
+	}
+	tms.events.publish(event)
+}
+
+// setConnected records the new connection, the caller must hold the connection lock.
+func (tms *IggyTcpClient) setConnected(conn net.Conn) {
+	tms.conn = conn
+	tms.connected.Store(true)
+	tms.emit(Connected, nil)
+}
+
+// disconnected reports the loss of the connection with the error which caused it, once per connection.
+func (tms *IggyTcpClient) disconnected(err error) {
+	if tms.connected.CompareAndSwap(true, false) {
+		tms.emit(Disconnected, err)
+	}
+}
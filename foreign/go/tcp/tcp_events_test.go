@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEvents_ReportsConnectionAndSession(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	client, err := newTestTcpClient(t, server.address(),
+		WithReconnectInterval(10*time.Millisecond, 10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	events, _ := client.SubscribeEvents(100)
+
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	server.dropConnections()
+	if err := client.Ping(); err == nil {
+		t.Fatal("expected the ping on the dropped connection to fail")
+	}
+	if err := client.LogoutUser(); err != nil {
+		t.Fatalf("failed to logout: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("failed to close the client: %v", err)
+	}
+
+	var kinds []EventKind
+	for event := range events {
+		if event.Time.IsZero() || event.Address != server.address() {
+			t.Fatalf("expected the event to have a time and the server address, got %+v", event)
+		}
+		if event.Kind == Disconnected && len(kinds) == 1 && event.Err == nil {
+			t.Fatal("expected the broken connection to be reported with its error")
+		}
+		kinds = append(kinds, event.Kind)
+	}
+	expected := []EventKind{Authenticated, Disconnected, Reconnecting, Connected, Authenticated, LoggedOut, Disconnected}
+	if !slices.Equal(kinds, expected) {
+		t.Fatalf("expected the events %v, got %v", expected, kinds)
+	}
+}
+
+func TestEvents_HandlerReportsHeartbeatFailures(t *testing.T) {
+	server := startTestServer(t, pingHandler)
+	events := make(chan Event, 100)
+	_, err := newTestTcpClient(t, server.address(),
+		WithHeartbeatInterval(10*time.Millisecond),
+		WithEventHandler(func(event Event) { events <- event }),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if event := <-events; event.Kind != Connected {
+		t.Fatalf("expected the first event to be %v, got %v", Connected, event.Kind)
+	}
+
+	server.dropConnections()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Kind == HeartbeatFailed {
+				if event.Err == nil {
+					t.Fatal("expected the failed heartbeat to be reported with its error")
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("the failed heartbeat was not reported")
+		}
+	}
+}
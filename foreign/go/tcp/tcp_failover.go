@@ -0,0 +1,223 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"sync/atomic"
+	"time"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// FailoverOrder is the order in which the client tries the server addresses.
+type FailoverOrder int
+
+const (
+	// Ordered tries the addresses in the given order, the first one being the primary.
+	Ordered FailoverOrder = iota
+	// Randomized tries the addresses in a random order.
+	Randomized
+)
+
+// FailoverOptions configures how the client moves between the addresses set with WithServerAddresses.
+// A connection which fails to open or breaks marks its address as failed, and the client moves to the next
+// healthy one: right away with the reconnect enabled, or with the next request otherwise.
+// The failed addresses are only tried once all the healthy ones failed too.
+type FailoverOptions struct {
+	Order FailoverOrder
+	// ProbeInterval is how often the failed addresses are probed with a ping, 0 disables the probing.
+	ProbeInterval time.Duration
+	// Failback moves the connection back to the primary address as soon as a probe finds it healthy,
+	// the session being restored as on a reconnect. It only applies to the Ordered order.
+	Failback bool
+}
+
+// WithServerAddresses sets several server addresses for the TCP client, e.g. a primary node and its standby.
+func WithServerAddresses(addresses ...string) Option {
+	return func(opts *Options) {
+		if len(addresses) > 0 {
+			opts.ServerAddress = addresses[0]
+		}
+		opts.ServerAddresses = addresses
+	}
+}
+
+// WithFailoverOrder sets the order in which the server addresses are tried.
+func WithFailoverOrder(order FailoverOrder) Option {
+	return func(opts *Options) {
+		opts.Failover.Order = order
+	}
+}
+
+// WithFailoverProbeInterval sets how often the failed server addresses are probed, 0 disables the probing.
+func WithFailoverProbeInterval(interval time.Duration) Option {
+	return func(opts *Options) {
+		opts.Failover.ProbeInterval = interval
+	}
+}
+
+// WithFailback sets whether the connection moves back to the primary address once it is healthy again.
+func WithFailback(failback bool) Option {
+	return func(opts *Options) {
+		opts.Failover.Failback = failback
+	}
+}
+
+type endpoint struct {
+	address string
+	dial    func(ctx context.Context) (net.Conn, error)
+	healthy atomic.Bool
+}
+
+func newEndpoint(address string, dial func(ctx context.Context) (net.Conn, error)) *endpoint {
+	e := &endpoint{address: address, dial: dial}
+	e.healthy.Store(true)
+	return e
+}
+
+// probe opens a separate connection to the endpoint and pings the server through it.
+func (e *endpoint) probe(ctx context.Context) error {
+	conn, err := e.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	if err := newFrame([]byte{}, PingCode).writeTo(conn); err != nil {
+		return err
+	}
+	_, err = readResponse(conn)
+	return err
+}
+
+type failover struct {
+	options   FailoverOptions
+	endpoints []*endpoint
+	current   atomic.Pointer[endpoint]
+}
+
+func (f *failover) multiple() bool {
+	return len(f.endpoints) > 1
+}
+
+// dial opens a connection to the first endpoint accepting it, trying the healthy endpoints first.
+func (f *failover) dial(ctx context.Context) (net.Conn, error) {
+	var errs []error
+	for _, endpoint := range f.candidates() {
+		conn, err := endpoint.dial(ctx)
+		if err == nil {
+			endpoint.healthy.Store(true)
+			f.current.Store(endpoint)
+			return conn, nil
+		}
+		endpoint.healthy.Store(false)
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (f *failover) candidates() []*endpoint {
+	endpoints := make([]*endpoint, len(f.endpoints))
+	copy(endpoints, f.endpoints)
+	if f.options.Order == Randomized {
+		rand.Shuffle(len(endpoints), func(i, j int) {
+			endpoints[i], endpoints[j] = endpoints[j], endpoints[i]
+		})
+	}
+
+	candidates := make([]*endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint.healthy.Load() {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	for _, endpoint := range endpoints {
+		if !endpoint.healthy.Load() {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	return candidates
+}
+
+// markFailed marks the endpoint of the current connection as failed.
+func (f *failover) markFailed() {
+	if endpoint := f.current.Load(); endpoint != nil {
+		endpoint.healthy.Store(false)
+	}
+}
+
+// shouldFailback reports whether the connection is away from the primary endpoint while it is healthy.
+func (f *failover) shouldFailback() bool {
+	primary := f.endpoints[0]
+	return f.options.Failback && f.options.Order == Ordered && primary.healthy.Load() && f.current.Load() != primary
+}
+
+func (tms *IggyTcpClient) probeEndpoints(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tms.ctx.Done():
+			return
+		case <-ticker.C:
+			for _, endpoint := range tms.failover.endpoints {
+				if endpoint.healthy.Load() {
+					continue
+				}
+				ctx, cancel := context.WithTimeout(tms.ctx, interval)
+				if endpoint.probe(ctx) == nil {
+					endpoint.healthy.Store(true)
+				}
+				cancel()
+			}
+			if tms.failover.shouldFailback() {
+				tms.failback()
+			}
+		}
+	}
+}
+
+// failback moves the connection to the primary endpoint, which is the first one tried as long as it is healthy.
+// In the pipelined mode, it waits for the next probe while requests are in flight rather than failing them.
+func (tms *IggyTcpClient) failback() {
+	if err := tms.lock(tms.ctx); err != nil {
+		return
+	}
+	defer tms.unlock()
+	if tms.closed.Load() || !tms.failover.shouldFailback() {
+		return
+	}
+	if tms.pipeline != nil && tms.pipeline.busy() {
+		return
+	}
+	_ = tms.restoreConnection(tms.ctx)
+}
@@ -0,0 +1,209 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// waitForCommand waits until the recorder receives the given command.
+func waitForCommand(t *testing.T, recorder *commandRecorder, command iggcon.CommandCode) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !slices.Contains(recorder.received(), command) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the command %v, got %v", command, recorder.received())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFailover_DialsNextAddress(t *testing.T) {
+	down := startTestServer(t, pingHandler)
+	down.close()
+	standby := startTestServer(t, pingHandler)
+
+	client, err := newTestTcpClient(t, "", WithServerAddresses(down.address(), standby.address()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the ping through the standby to succeed, got %v", err)
+	}
+	if current := client.failover.current.Load(); current.address != standby.address() {
+		t.Fatalf("expected to be connected to %s, got %s", standby.address(), current.address)
+	}
+}
+
+func TestFailover_MovesToNextAddressWithSession(t *testing.T) {
+	primaryRecorder, standbyRecorder := &commandRecorder{}, &commandRecorder{}
+	primary := startTestServer(t, primaryRecorder.handle)
+	standby := startTestServer(t, standbyRecorder.handle)
+	client, err := newTestTcpClient(t, "",
+		WithServerAddresses(primary.address(), standby.address()),
+		WithFailoverProbeInterval(0),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	primary.close()
+	if err := client.Ping(); err == nil {
+		t.Fatal("expected the ping on the broken connection to fail")
+	}
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the ping through the standby to succeed, got %v", err)
+	}
+	expected := []iggcon.CommandCode{iggcon.LoginUserCode, iggcon.GetStatsCode, iggcon.PingCode}
+	if commands := standbyRecorder.received(); !slices.Equal(commands, expected) {
+		t.Fatalf("expected the standby to receive %v, got %v", expected, commands)
+	}
+}
+
+func TestFailover_DoesNotCallReconnectCallbackWithoutReconnect(t *testing.T) {
+	primary := startTestServer(t, pingHandler)
+	standby := startTestServer(t, pingHandler)
+	var calls atomic.Int32
+	client, err := newTestTcpClient(t, "",
+		WithServerAddresses(primary.address(), standby.address()),
+		WithFailoverProbeInterval(0),
+		func(opts *Options) {
+			opts.Reconnect.OnReconnect = func(error) { calls.Add(1) }
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	primary.close()
+	_ = client.Ping()
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the ping through the standby to succeed, got %v", err)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("expected the reconnect callback not to be called, got %d calls", n)
+	}
+}
+
+func TestFailover_FailsBackToPrimary(t *testing.T) {
+	primaryRecorder, standbyRecorder := &commandRecorder{}, &commandRecorder{}
+	primary := startTestServer(t, primaryRecorder.handle)
+	standby := startTestServer(t, standbyRecorder.handle)
+	primaryAddress := primary.address()
+	client, err := newTestTcpClient(t, "",
+		WithServerAddresses(primaryAddress, standby.address()),
+		WithFailoverProbeInterval(10*time.Millisecond),
+		WithReconnectInterval(10*time.Millisecond, 10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	primary.close()
+	_ = client.Ping()
+	waitForCommand(t, standbyRecorder, iggcon.LoginUserCode)
+
+	primaryRecorder.reset()
+	startTestServerAt(t, primaryAddress, primaryRecorder.handle)
+	waitForCommand(t, primaryRecorder, iggcon.LoginUserCode)
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the ping through the primary to succeed, got %v", err)
+	}
+	if current := client.failover.current.Load(); current.address != primaryAddress {
+		t.Fatalf("expected to be connected back to %s, got %s", primaryAddress, current.address)
+	}
+}
+
+func TestFailover_FailbackWaitsForPipelinedRequests(t *testing.T) {
+	primaryRecorder, standbyRecorder := &commandRecorder{}, &commandRecorder{}
+	primary := startTestServer(t, primaryRecorder.handle)
+	var blocking atomic.Bool
+	received, release := make(chan struct{}, 1), make(chan struct{})
+	unblock := sync.OnceFunc(func() { close(release) })
+	standby := startTestServer(t, func(command iggcon.CommandCode, payload []byte) (uint32, []byte) {
+		if command == iggcon.PingCode && blocking.Load() {
+			received <- struct{}{}
+			<-release
+		}
+		return standbyRecorder.handle(command, payload)
+	})
+	t.Cleanup(unblock)
+	primaryAddress := primary.address()
+	client, err := newTestTcpClient(t, "",
+		WithServerAddresses(primaryAddress, standby.address()),
+		WithPipelining(8),
+		WithFailoverProbeInterval(10*time.Millisecond),
+		WithReconnectInterval(10*time.Millisecond, 10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	primary.close()
+	_ = client.Ping()
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the ping through the standby to succeed, got %v", err)
+	}
+	blocking.Store(true)
+	result := make(chan error, 1)
+	go func() {
+		result <- client.Ping()
+	}()
+	<-received
+
+	primaryRecorder.reset()
+	startTestServerAt(t, primaryAddress, primaryRecorder.handle)
+	deadline := time.Now().Add(5 * time.Second)
+	for !client.failover.endpoints[0].healthy.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("the primary was not probed healthy")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if current := client.failover.current.Load(); current.address != standby.address() {
+		t.Fatalf("expected to stay on %s while a request is in flight, got %s", standby.address(), current.address)
+	}
+
+	unblock()
+	if err := <-result; err != nil {
+		t.Fatalf("expected the request in flight to succeed, got %v", err)
+	}
+	waitForCommand(t, primaryRecorder, iggcon.LoginUserCode)
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the ping through the primary to succeed, got %v", err)
+	}
+	if current := client.failover.current.Load(); current.address != primaryAddress {
+		t.Fatalf("expected to be connected back to %s, got %s", primaryAddress, current.address)
+	}
+}
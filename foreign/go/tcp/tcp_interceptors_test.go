@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func TestInterceptors_WrapCommandsInOrder(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	var order []string
+	tracing := func(name string) iggcon.CommandInterceptor {
+		return func(ctx context.Context, command iggcon.CommandCode, request []byte, next iggcon.CommandInvoker) ([]byte, error) {
+			order = append(order, name+" before")
+			response, err := next(ctx, command, request)
+			order = append(order, name+" after")
+			return response, err
+		}
+	}
+	var calls []iggcon.CommandCall
+	client, err := newTestTcpClient(t, server.address(),
+		WithInterceptors(tracing("outer"), tracing("inner")),
+		WithInterceptors(iggcon.ObserveCommands(func(call iggcon.CommandCall) { calls = append(calls, call) })),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	// The login is followed by the detection of the server version.
+	expected := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !slices.Equal(order, append(expected, expected...)) {
+		t.Errorf("expected the interceptors to run as %v for each command, got %v", expected, order)
+	}
+	if len(calls) != 2 || calls[0].Command != iggcon.LoginUserCode || calls[0].Err != nil || calls[0].Duration <= 0 || calls[1].Command != iggcon.GetStatsCode {
+		t.Fatalf("unexpected observed calls: %+v", calls)
+	}
+	if !slices.Equal(calls[0].Response, []byte{1, 0, 0, 0}) || len(calls[0].Request) == 0 {
+		t.Errorf("expected the observed call to hold the request and the response, got %+v", calls[0])
+	}
+}
+
+func TestInterceptors_ShortCircuit(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	denied := errors.New("denied")
+	client, err := newTestTcpClient(t, server.address(), WithInterceptors(
+		func(ctx context.Context, command iggcon.CommandCode, request []byte, next iggcon.CommandInvoker) ([]byte, error) {
+			if command == iggcon.DeleteStreamCode {
+				return nil, denied
+			}
+			return next(ctx, command, request)
+		},
+	))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if err := client.DeleteStream(iggcon.NewIdentifier(1)); !errors.Is(err, denied) {
+		t.Fatalf("expected the interceptor error, got %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Fatalf("failed to ping: %v", err)
+	}
+	if received := recorder.received(); !slices.Equal(received, []iggcon.CommandCode{iggcon.PingCode}) {
+		t.Errorf("expected only the ping to reach the server, got %v", received)
+	}
+}
+
+func TestInterceptors_Retry(t *testing.T) {
+	var attempts atomic.Int32
+	server := startTestServer(t, func(command iggcon.CommandCode, _ []byte) (uint32, []byte) {
+		if command == iggcon.SendMessagesCode && attempts.Add(1) == 1 {
+			return 3, nil
+		}
+		return 0, nil
+	})
+	client, err := newTestTcpClient(t, server.address(), WithInterceptors(
+		func(ctx context.Context, command iggcon.CommandCode, request []byte, next iggcon.CommandInvoker) ([]byte, error) {
+			response, err := next(ctx, command, request)
+			if err != nil {
+				return next(ctx, command, request)
+			}
+			return response, err
+		},
+	))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	message, _ := iggcon.NewIggyMessage([]byte("message"))
+	err = client.SendMessages(iggcon.NewIdentifier(1), iggcon.NewIdentifier(2), iggcon.PartitionId(1), []iggcon.IggyMessage{message})
+	if err != nil {
+		t.Fatalf("expected the retried command to succeed, got %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
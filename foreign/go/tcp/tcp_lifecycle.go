@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"time"
+
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+// logoutOnCloseTimeout bounds the logout done by Close, so that a server which stopped responding does not block it.
+const logoutOnCloseTimeout = 5 * time.Second
+
+// WithHeartbeatInterval sets how often the connection is checked with a ping, 0 disables the heartbeat.
+func WithHeartbeatInterval(interval time.Duration) Option {
+	return func(opts *Options) {
+		opts.HeartbeatInterval = interval
+	}
+}
+
+// WithHeartbeatCallback sets the function called after each heartbeat with nil on success, or with the error of the failed ping.
+func WithHeartbeatCallback(onHeartbeat func(err error)) Option {
+	return func(opts *Options) {
+		opts.OnHeartbeat = onHeartbeat
+	}
+}
+
+// WithLogoutOnClose makes Close log the user out before closing the connection.
+func WithLogoutOnClose() Option {
+	return func(opts *Options) {
+		opts.LogoutOnClose = true
+	}
+}
+
+// Healthy reports whether the last heartbeat succeeded. It is false once the client is closed.
+func (tms *IggyTcpClient) Healthy() bool {
+	return tms.healthy.Load() && !tms.closed.Load()
+}
+
+// Close stops the heartbeat and closes the connection, interrupting the requests in flight.
+// The later calls fail with ierror.ClientShutdown. With LogoutOnClose, a logged-in user is logged out first,
+// and the error of the logout is returned after the client is closed anyway.
+// The logout is given at most a few seconds, and only the first of concurrent calls does it.
+func (tms *IggyTcpClient) Close() error {
+	if !tms.closing.CompareAndSwap(false, true) {
+		return ierror.ClientShutdown
+	}
+
+	var err error
+	if tms.logoutOnClose && tms.loggedIn() {
+		ctx, cancel := context.WithTimeout(tms.ctx, tms.logoutTimeout)
+		err = tms.LogoutUserCtx(ctx)
+		cancel()
+	}
+	tms.closed.Store(true)
+	tms.cancel()
+
+	_ = tms.lock(context.Background())
+	defer tms.unlock()
+	tms.closeConnection()
+	tms.events.close()
+	return err
+}
+
+func (tms *IggyTcpClient) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tms.ctx.Done():
+			return
+		case <-ticker.C:
+			// A failed ping triggers the reconnect when it is enabled.
+			ctx, cancel := context.WithTimeout(tms.ctx, interval)
+			err := tms.PingCtx(ctx)
+			cancel()
+			if tms.closed.Load() {
+				return
+			}
+			tms.healthy.Store(err == nil)
+			if err != nil {
+				tms.emit(HeartbeatFailed, err)
+			}
+			if tms.onHeartbeat != nil {
+				tms.onHeartbeat(err)
+			}
+		}
+	}
+}
+
+func (tms *IggyTcpClient) loggedIn() bool {
+	tms.sessionMtx.Lock()
+	defer tms.sessionMtx.Unlock()
+	return tms.session.loginMessage != nil
+}
@@ -0,0 +1,210 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func TestClose_LaterCallsFail(t *testing.T) {
+	server := startTestServer(t, pingHandler)
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("failed to close the client: %v", err)
+	}
+	if err := client.Ping(); !errors.Is(err, ierror.ClientShutdown) {
+		t.Fatalf("expected %v after closing, got %v", ierror.ClientShutdown, err)
+	}
+	if err := client.Close(); !errors.Is(err, ierror.ClientShutdown) {
+		t.Fatalf("expected %v when closing again, got %v", ierror.ClientShutdown, err)
+	}
+	if client.Healthy() {
+		t.Fatal("expected a closed client not to be healthy")
+	}
+}
+
+func TestClose_LogsOut(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	client, err := newTestTcpClient(t, server.address(), WithLogoutOnClose())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("failed to close the client: %v", err)
+	}
+	expected := []iggcon.CommandCode{iggcon.LoginUserCode, iggcon.GetStatsCode, iggcon.LogoutUserCode}
+	if commands := recorder.received(); !slices.Equal(commands, expected) {
+		t.Fatalf("expected %v, got %v", expected, commands)
+	}
+}
+
+func TestClose_BoundsLogout(t *testing.T) {
+	recorder, release := &commandRecorder{}, make(chan struct{})
+	server := startTestServer(t, func(command iggcon.CommandCode, payload []byte) (uint32, []byte) {
+		if command == iggcon.LogoutUserCode {
+			<-release
+		}
+		return recorder.handle(command, payload)
+	})
+	t.Cleanup(sync.OnceFunc(func() { close(release) }))
+	client, err := newTestTcpClient(t, server.address(), WithLogoutOnClose())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	client.logoutTimeout = 50 * time.Millisecond
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- client.Close()
+	}()
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the logout to time out, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the logout of an unresponsive server blocked Close")
+	}
+	if err := client.Ping(); !errors.Is(err, ierror.ClientShutdown) {
+		t.Fatalf("expected %v after closing, got %v", ierror.ClientShutdown, err)
+	}
+}
+
+func TestClose_ConcurrentCallsLogOutOnce(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	client, err := newTestTcpClient(t, server.address(), WithLogoutOnClose())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	const calls = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, calls)
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.Is(err, ierror.ClientShutdown) {
+			t.Fatalf("expected %v for the other calls, got %v", ierror.ClientShutdown, err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("expected a single call to close the client, got %d", succeeded)
+	}
+	logouts := 0
+	for _, command := range recorder.received() {
+		if command == iggcon.LogoutUserCode {
+			logouts++
+		}
+	}
+	if logouts != 1 {
+		t.Fatalf("expected a single logout, got %d", logouts)
+	}
+}
+
+func TestClose_InterruptsRequestInFlight(t *testing.T) {
+	handler, received, unblock := blockingHandler()
+	server := startTestServer(t, handler)
+	t.Cleanup(unblock)
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- client.PingCtx(context.Background())
+	}()
+	<-received
+	if err := client.Close(); err != nil {
+		t.Fatalf("failed to close the client: %v", err)
+	}
+	select {
+	case err := <-result:
+		if !errors.Is(err, ierror.ClientShutdown) {
+			t.Fatalf("expected %v, got %v", ierror.ClientShutdown, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request in flight was not interrupted")
+	}
+}
+
+func TestHeartbeat_ReportsFailures(t *testing.T) {
+	server := startTestServer(t, pingHandler)
+	outcomes := make(chan error, 100)
+	client, err := newTestTcpClient(t, server.address(),
+		WithHeartbeatInterval(10*time.Millisecond),
+		WithHeartbeatCallback(func(err error) { outcomes <- err }),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := <-outcomes; err != nil {
+		t.Fatalf("expected the heartbeat to succeed, got %v", err)
+	}
+
+	server.dropConnections()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case err := <-outcomes:
+			if err == nil {
+				continue
+			}
+			if client.Healthy() {
+				t.Fatal("expected the client to be unhealthy after a failed heartbeat")
+			}
+			return
+		case <-timeout:
+			t.Fatal("the failed heartbeat was not reported")
+		}
+	}
+}
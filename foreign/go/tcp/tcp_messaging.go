@@ -18,16 +18,53 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
+// ChecksumOptions configures the CRC32 checksums of the messages, computed like the server does over the message
+// as sent over the wire, so over the compressed payload when a message compression is used.
+type ChecksumOptions struct {
+	// Fill sets the checksum of the sent messages instead of leaving it to zero.
+	// The server does not check it, and replaces it once it assigns the offset and the timestamp of the message.
+	Fill bool
+	// Verify checks the checksum of the polled messages, the poll then fails with an *ierror.MessageChecksumError
+	// for the first message whose content does not match it.
+	Verify bool
+}
+
+// WithMessageChecksums fills the checksum of the sent messages.
+func WithMessageChecksums() Option {
+	return func(opts *Options) {
+		opts.Checksums.Fill = true
+	}
+}
+
+// WithChecksumVerification verifies the checksum of the polled messages.
+func WithChecksumVerification() Option {
+	return func(opts *Options) {
+		opts.Checksums.Verify = true
+	}
+}
+
 func (tms *IggyTcpClient) SendMessages(
 	streamId Identifier,
 	topicId Identifier,
 	partitioning Partitioning,
 	messages []IggyMessage,
+) error {
+	return tms.SendMessagesCtx(tms.ctx, streamId, topicId, partitioning, messages)
+}
+
+func (tms *IggyTcpClient) SendMessagesCtx(
+	ctx context.Context,
+	streamId Identifier,
+	topicId Identifier,
+	partitioning Partitioning,
+	messages []IggyMessage,
 ) error {
 	if len(messages) == 0 {
 		return ierror.CustomError("messages_count_should_be_greater_than_zero")
@@ -37,8 +74,14 @@ func (tms *IggyTcpClient) SendMessages(
 		TopicId:      topicId,
 		Partitioning: partitioning,
 		Messages:     messages,
+		Checksums:    tms.checksums.Fill,
+	}
+	// The interceptors see the serialized request, so the payloads are only referenced by the frame without them.
+	if len(tms.interceptors) > 0 {
+		_, err := tms.sendAndFetchResponse(ctx, serializedRequest.Serialize(tms.MessageCompression), SendMessagesCode)
+		return err
 	}
-	_, err := tms.sendAndFetchResponse(serializedRequest.Serialize(tms.MessageCompression), SendMessagesCode)
+	_, err := tms.sendFrameAndFetchResponse(ctx, newSendMessagesFrame(&serializedRequest, tms.MessageCompression))
 	return err
 }
 
@@ -50,6 +93,19 @@ func (tms *IggyTcpClient) PollMessages(
 	count uint32,
 	autoCommit bool,
 	partitionId *uint32,
+) (*PolledMessage, error) {
+	return tms.PollMessagesCtx(tms.ctx, streamId, topicId, consumer, strategy, count, autoCommit, partitionId)
+}
+
+func (tms *IggyTcpClient) PollMessagesCtx(
+	ctx context.Context,
+	streamId Identifier,
+	topicId Identifier,
+	consumer Consumer,
+	strategy PollingStrategy,
+	count uint32,
+	autoCommit bool,
+	partitionId *uint32,
 ) (*PolledMessage, error) {
 	serializedRequest := binaryserialization.TcpFetchMessagesRequest{
 		StreamId:    streamId,
@@ -60,10 +116,77 @@ func (tms *IggyTcpClient) PollMessages(
 		Count:       count,
 		PartitionId: partitionId,
 	}
-	buffer, err := tms.sendAndFetchResponse(serializedRequest.Serialize(), PollMessagesCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, serializedRequest.Serialize(), PollMessagesCode)
 	if err != nil {
 		return nil, err
 	}
+	if tms.checksums.Verify {
+		if err := binaryserialization.DeserializeFetchMessagesView(buffer, tms.MessageCompression).VerifyChecksums(); err != nil {
+			return nil, err
+		}
+	}
 
 	return binaryserialization.DeserializeFetchMessagesResponse(buffer, tms.MessageCompression)
 }
+
+// PollMessageViews polls the messages like PollMessages but leaves them in the response buffer, so that consumers
+// inspecting the headers to filter messages only copy and decompress the ones they keep.
+func (tms *IggyTcpClient) PollMessageViews(
+	streamId Identifier,
+	topicId Identifier,
+	consumer Consumer,
+	strategy PollingStrategy,
+	count uint32,
+	autoCommit bool,
+	partitionId *uint32,
+) (*PolledMessageView, error) {
+	return tms.PollMessageViewsCtx(tms.ctx, streamId, topicId, consumer, strategy, count, autoCommit, partitionId)
+}
+
+func (tms *IggyTcpClient) PollMessageViewsCtx(
+	ctx context.Context,
+	streamId Identifier,
+	topicId Identifier,
+	consumer Consumer,
+	strategy PollingStrategy,
+	count uint32,
+	autoCommit bool,
+	partitionId *uint32,
+) (*PolledMessageView, error) {
+	serializedRequest := binaryserialization.TcpFetchMessagesRequest{
+		StreamId:    streamId,
+		TopicId:     topicId,
+		Consumer:    consumer,
+		AutoCommit:  autoCommit,
+		Strategy:    strategy,
+		Count:       count,
+		PartitionId: partitionId,
+	}
+	buffer, err := tms.sendAndFetchResponse(ctx, serializedRequest.Serialize(), PollMessagesCode)
+	if err != nil {
+		return nil, err
+	}
+	polled := binaryserialization.DeserializeFetchMessagesView(buffer, tms.MessageCompression)
+	if tms.checksums.Verify {
+		if err := polled.VerifyChecksums(); err != nil {
+			return nil, err
+		}
+	}
+
+	return polled, nil
+}
+
+func (tms *IggyTcpClient) FlushUnsavedBuffer(streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error {
+	return tms.FlushUnsavedBufferCtx(tms.ctx, streamId, topicId, partitionId, fsync)
+}
+
+func (tms *IggyTcpClient) FlushUnsavedBufferCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error {
+	message := binaryserialization.FlushUnsavedBuffer(FlushUnsavedBufferRequest{
+		StreamId:    streamId,
+		TopicId:     topicId,
+		PartitionId: partitionId,
+		Fsync:       fsync,
+	})
+	_, err := tms.sendAndFetchResponse(ctx, message, FlushUnsavedBufferCode)
+	return err
+}
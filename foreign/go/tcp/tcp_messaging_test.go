@@ -0,0 +1,106 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"sync"
+	"testing"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func TestChecksums_FilledOnSendAndVerifiedOnPoll(t *testing.T) {
+	var mtx sync.Mutex
+	var stored []byte
+	corrupt := false
+	server := startTestServer(t, func(command iggcon.CommandCode, payload []byte) (uint32, []byte) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		switch command {
+		case iggcon.SendMessagesCode:
+			metadataLength := int(binary.LittleEndian.Uint32(payload[0:4]))
+			count := int(binary.LittleEndian.Uint32(payload[metadataLength : metadataLength+4]))
+			stored = bytes.Clone(payload[4+metadataLength+16*count:])
+		case iggcon.PollMessagesCode:
+			response := binary.LittleEndian.AppendUint32(nil, 3)
+			response = binary.LittleEndian.AppendUint64(response, 41)
+			response = binary.LittleEndian.AppendUint32(response, 1)
+			response = append(response, stored...)
+			if corrupt {
+				response[len(response)-1] ^= 0x01
+			}
+			return 0, response
+		}
+		return 0, nil
+	})
+	client, err := newTestTcpClient(t, server.address(), WithMessageChecksums(), WithChecksumVerification())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	client.MessageCompression = iggcon.MESSAGE_COMPRESSION_S2
+
+	payload := bytes.Repeat([]byte("checksum"), 16)
+	message, err := iggcon.NewIggyMessage(payload, iggcon.WithUserHeaders(map[iggcon.HeaderKey]iggcon.HeaderValue{
+		{Value: "key"}: {Kind: iggcon.String, Value: []byte("value")},
+	}))
+	if err != nil {
+		t.Fatalf("failed to create the message: %v", err)
+	}
+	stream, topic := iggcon.NewIdentifier(1), iggcon.NewIdentifier(2)
+	if err := client.SendMessages(stream, topic, iggcon.PartitionId(3), []iggcon.IggyMessage{message}); err != nil {
+		t.Fatalf("failed to send the messages: %v", err)
+	}
+
+	mtx.Lock()
+	if checksum := binary.LittleEndian.Uint64(stored[0:8]); checksum == 0 || checksum != uint64(crc32.ChecksumIEEE(stored[8:])) {
+		t.Errorf("expected the checksum of the sent message, got %d", checksum)
+	}
+	// The server assigns the offset and computes the checksum again.
+	binary.LittleEndian.PutUint64(stored[24:32], 41)
+	binary.LittleEndian.PutUint64(stored[0:8], uint64(crc32.ChecksumIEEE(stored[8:])))
+	mtx.Unlock()
+
+	consumer := iggcon.Consumer{Kind: iggcon.ConsumerKindSingle, Id: iggcon.NewIdentifier(1)}
+	polled, err := client.PollMessages(stream, topic, consumer, iggcon.FirstPollingStrategy(), 1, false, nil)
+	if err != nil {
+		t.Fatalf("failed to poll the messages: %v", err)
+	}
+	if len(polled.Messages) != 1 || !bytes.Equal(polled.Messages[0].Payload, payload) {
+		t.Fatalf("expected the decompressed message, got %v", polled.Messages)
+	}
+
+	mtx.Lock()
+	corrupt = true
+	mtx.Unlock()
+	_, err = client.PollMessages(stream, topic, consumer, iggcon.FirstPollingStrategy(), 1, false, nil)
+	var checksumErr *ierror.MessageChecksumError
+	if !errors.As(err, &checksumErr) || !errors.Is(err, ierror.InvalidMessageChecksum) {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	if checksumErr.PartitionId != 3 || checksumErr.Offset != 41 {
+		t.Errorf("expected the error to report partition 3 and offset 41, got %v", checksumErr)
+	}
+	if _, err := client.PollMessageViews(stream, topic, consumer, iggcon.FirstPollingStrategy(), 1, false, nil); !errors.Is(err, ierror.InvalidMessageChecksum) {
+		t.Errorf("expected the message views to be verified too, got %v", err)
+	}
+}
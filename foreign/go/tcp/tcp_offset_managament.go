@@ -18,18 +18,24 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 )
 
 func (tms *IggyTcpClient) GetConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) (*ConsumerOffsetInfo, error) {
+	return tms.GetConsumerOffsetCtx(tms.ctx, consumer, streamId, topicId, partitionId)
+}
+
+func (tms *IggyTcpClient) GetConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) (*ConsumerOffsetInfo, error) {
 	message := binaryserialization.GetOffset(GetConsumerOffsetRequest{
 		StreamId:    streamId,
 		TopicId:     topicId,
 		Consumer:    consumer,
 		PartitionId: partitionId,
 	})
-	buffer, err := tms.sendAndFetchResponse(message, GetOffsetCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetOffsetCode)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +44,10 @@ func (tms *IggyTcpClient) GetConsumerOffset(consumer Consumer, streamId Identifi
 }
 
 func (tms *IggyTcpClient) StoreConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, offset uint64, partitionId *uint32) error {
+	return tms.StoreConsumerOffsetCtx(tms.ctx, consumer, streamId, topicId, offset, partitionId)
+}
+
+func (tms *IggyTcpClient) StoreConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, offset uint64, partitionId *uint32) error {
 	message := binaryserialization.UpdateOffset(StoreConsumerOffsetRequest{
 		StreamId:    streamId,
 		TopicId:     topicId,
@@ -45,6 +55,21 @@ func (tms *IggyTcpClient) StoreConsumerOffset(consumer Consumer, streamId Identi
 		Consumer:    consumer,
 		PartitionId: partitionId,
 	})
-	_, err := tms.sendAndFetchResponse(message, StoreOffsetCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, StoreOffsetCode)
+	return err
+}
+
+func (tms *IggyTcpClient) DeleteConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) error {
+	return tms.DeleteConsumerOffsetCtx(tms.ctx, consumer, streamId, topicId, partitionId)
+}
+
+func (tms *IggyTcpClient) DeleteConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) error {
+	message := binaryserialization.DeleteOffset(DeleteConsumerOffsetRequest{
+		StreamId:    streamId,
+		TopicId:     topicId,
+		Consumer:    consumer,
+		PartitionId: partitionId,
+	})
+	_, err := tms.sendAndFetchResponse(ctx, message, DeleteOffsetCode)
 	return err
 }
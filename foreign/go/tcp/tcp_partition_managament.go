@@ -18,26 +18,51 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 )
 
 func (tms *IggyTcpClient) CreatePartitions(streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return tms.CreatePartitionsCtx(tms.ctx, streamId, topicId, partitionsCount)
+}
+
+func (tms *IggyTcpClient) CreatePartitionsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionsCount uint32) error {
 	message := binaryserialization.CreatePartitions(CreatePartitionsRequest{
 		StreamId:        streamId,
 		TopicId:         topicId,
 		PartitionsCount: partitionsCount,
 	})
-	_, err := tms.sendAndFetchResponse(message, CreatePartitionsCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, CreatePartitionsCode)
 	return err
 }
 
 func (tms *IggyTcpClient) DeletePartitions(streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return tms.DeletePartitionsCtx(tms.ctx, streamId, topicId, partitionsCount)
+}
+
+func (tms *IggyTcpClient) DeletePartitionsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionsCount uint32) error {
 	message := binaryserialization.DeletePartitions(DeletePartitionsRequest{
 		StreamId:        streamId,
 		TopicId:         topicId,
 		PartitionsCount: partitionsCount,
 	})
-	_, err := tms.sendAndFetchResponse(message, DeletePartitionsCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, DeletePartitionsCode)
+	return err
+}
+
+func (tms *IggyTcpClient) DeleteSegments(streamId Identifier, topicId Identifier, partitionId uint32, segmentsCount uint32) error {
+	return tms.DeleteSegmentsCtx(tms.ctx, streamId, topicId, partitionId, segmentsCount)
+}
+
+func (tms *IggyTcpClient) DeleteSegmentsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionId uint32, segmentsCount uint32) error {
+	message := binaryserialization.DeleteSegments(DeleteSegmentsRequest{
+		StreamId:      streamId,
+		TopicId:       topicId,
+		PartitionId:   partitionId,
+		SegmentsCount: segmentsCount,
+	})
+	_, err := tms.sendAndFetchResponse(ctx, message, DeleteSegmentsCode)
 	return err
 }
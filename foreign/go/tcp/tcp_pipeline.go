@@ -0,0 +1,218 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// PipelineOptions configures the pipelined mode of the client.
+// In this mode a dedicated goroutine writes the requests without waiting for the responses to the previous ones,
+// and another one reads the responses and matches them to the requests in the order they were written,
+// so that the concurrent calls overlap on the connection.
+// A cancelled call stops waiting for its response, which is discarded when it arrives, and the connection is kept.
+// The context deadline is therefore not applied to the socket in this mode.
+// A broken connection is opened again and its session restored by the next call, in a single attempt
+// without the reconnect enabled.
+type PipelineOptions struct {
+	Enabled bool
+	// MaxInFlight is the maximum number of requests written before their response is read.
+	MaxInFlight int
+}
+
+// WithPipelining enables the pipelined mode, with at most maxInFlight requests waiting for their response.
+func WithPipelining(maxInFlight int) Option {
+	return func(opts *Options) {
+		opts.Pipeline.Enabled = true
+		opts.Pipeline.MaxInFlight = maxInFlight
+	}
+}
+
+type pipelineRequest struct {
+	frame   *frame
+	written chan struct{}
+	result  chan pipelineResult
+}
+
+type pipelineResult struct {
+	buffer []byte
+	err    error
+}
+
+// pipeline runs the writer and the reader of a single connection.
+type pipeline struct {
+	conn     net.Conn
+	requests chan *pipelineRequest
+	pending  chan *pipelineRequest
+	done     chan struct{}
+	onFail   func(err error)
+	once     sync.Once
+	err      error
+	// inFlight counts the calls which got the pipeline and did not return yet.
+	inFlight atomic.Int64
+}
+
+func startPipeline(conn net.Conn, maxInFlight int, onFail func(err error)) *pipeline {
+	p := &pipeline{
+		conn:     conn,
+		onFail:   onFail,
+		requests: make(chan *pipelineRequest),
+		pending:  make(chan *pipelineRequest, max(maxInFlight, 1)),
+		done:     make(chan struct{}),
+	}
+	go p.writeLoop()
+	go p.readLoop()
+	return p
+}
+
+func (p *pipeline) send(ctx context.Context, frame *frame) ([]byte, error) {
+	request := &pipelineRequest{
+		frame:   frame,
+		written: make(chan struct{}),
+		result:  make(chan pipelineResult, 1),
+	}
+	select {
+	case p.requests <- request:
+	case <-p.done:
+		frame.release()
+		return nil, p.err
+	case <-ctx.Done():
+		frame.release()
+		return nil, ctx.Err()
+	}
+
+	select {
+	case result := <-request.result:
+		return result.buffer, result.err
+	case <-p.done:
+		// The response might have been read right before the connection broke.
+		select {
+		case result := <-request.result:
+			return result.buffer, result.err
+		default:
+			return nil, p.err
+		}
+	case <-ctx.Done():
+		// The frame might reference the payloads of the caller, which are free to change once the call returns.
+		select {
+		case <-request.written:
+		case <-p.done:
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (p *pipeline) writeLoop() {
+	for {
+		select {
+		case <-p.done:
+			return
+		case request := <-p.requests:
+			// The request is queued before it is written, so that the reader never misses its response.
+			select {
+			case p.pending <- request:
+			case <-p.done:
+				request.frame.release()
+				return
+			}
+			if err := request.frame.writeTo(p.conn); err != nil {
+				p.fail(err)
+				return
+			}
+			close(request.written)
+		}
+	}
+}
+
+func (p *pipeline) readLoop() {
+	for {
+		select {
+		case <-p.done:
+			return
+		case request := <-p.pending:
+			buffer, err := readResponse(p.conn)
+			if err != nil && isConnectionError(err) {
+				p.fail(err)
+				return
+			}
+			request.result <- pipelineResult{buffer: buffer, err: err}
+		}
+	}
+}
+
+// fail stops the pipeline and closes its connection, the requests waiting for a response get the given error.
+func (p *pipeline) fail(err error) {
+	p.once.Do(func() {
+		p.err = err
+		close(p.done)
+		_ = p.conn.Close()
+		p.onFail(err)
+	})
+}
+
+// busy reports whether calls are still waiting for their response, or about to send their request.
+func (p *pipeline) busy() bool {
+	return p.inFlight.Load() > 0
+}
+
+func (p *pipeline) failed() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (tms *IggyTcpClient) sendPipelined(ctx context.Context, request *frame) ([]byte, error) {
+	p, err := tms.openPipeline(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.inFlight.Add(-1)
+
+	return p.send(ctx, request)
+}
+
+// openPipeline returns the pipeline of the connection, replacing the connection when it is broken and can be restored.
+// The call is counted in flight on the returned pipeline, under the connection lock so that a failback never misses it.
+func (tms *IggyTcpClient) openPipeline(ctx context.Context) (*pipeline, error) {
+	if err := tms.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer tms.unlock()
+
+	if tms.pipeline != nil {
+		if !tms.pipeline.failed() {
+			tms.pipeline.inFlight.Add(1)
+			return tms.pipeline, nil
+		}
+		tms.failover.markFailed()
+	}
+	if tms.conn == nil || tms.pipeline != nil {
+		if err := tms.restoreConnection(ctx); err != nil {
+			return nil, err
+		}
+	}
+	tms.pipeline = startPipeline(tms.conn, tms.pipelineOptions.MaxInFlight, tms.disconnected)
+	tms.pipeline.inFlight.Add(1)
+	return tms.pipeline, nil
+}
@@ -0,0 +1,175 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func TestPipeline_OverlapsRequests(t *testing.T) {
+	const requests = 3
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	// The server only answers once it has received all the requests, which never happens without the pipelining.
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		header := make([]byte, 8)
+		for range requests {
+			if _, err := io.ReadFull(conn, header); err != nil {
+				return
+			}
+			payload := make([]byte, binary.LittleEndian.Uint32(header[0:4])-4)
+			if _, err := io.ReadFull(conn, payload); err != nil {
+				return
+			}
+		}
+		for range requests {
+			if _, err := conn.Write(make([]byte, ExpectedResponseSize)); err != nil {
+				return
+			}
+		}
+	}()
+
+	client, err := newTestTcpClient(t, listener.Addr().String(), WithPipelining(requests))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.PingCtx(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("expected the pipelined requests to succeed, got %v", err)
+		}
+	}
+}
+
+func TestPipeline_CancelledCallKeepsConnection(t *testing.T) {
+	release := make(chan struct{})
+	server := startTestServer(t, func(_ iggcon.CommandCode, payload []byte) (uint32, []byte) {
+		if payload[0] == 1 {
+			<-release
+		}
+		return 0, payload
+	})
+	unblock := sync.OnceFunc(func() { close(release) })
+	t.Cleanup(unblock)
+	client, err := newTestTcpClient(t, server.address(), WithPipelining(8))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.sendAndFetchResponse(ctx, []byte{1, 1}, iggcon.PingCode); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	unblock()
+
+	// The response of the cancelled request is discarded rather than returned to the next one.
+	buffer, err := client.sendAndFetchResponse(context.Background(), []byte{2, 2}, iggcon.PingCode)
+	if err != nil {
+		t.Fatalf("expected the next request to succeed, got %v", err)
+	}
+	if !bytes.Equal(buffer, []byte{2, 2}) {
+		t.Fatalf("expected the response of the next request, got %v", buffer)
+	}
+	server.mtx.Lock()
+	defer server.mtx.Unlock()
+	if len(server.conns) != 1 {
+		t.Fatalf("expected the connection to be kept, got %d connections", len(server.conns))
+	}
+}
+
+func TestPipeline_BrokenConnectionFailsPendingRequests(t *testing.T) {
+	handler, received, unblock := blockingHandler()
+	server := startTestServer(t, handler)
+	t.Cleanup(unblock)
+	client, err := newTestTcpClient(t, server.address(),
+		WithPipelining(8),
+		WithReconnectInterval(10*time.Millisecond, 50*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- client.Ping()
+	}()
+	<-received
+	server.dropConnections()
+	select {
+	case err := <-result:
+		if err == nil {
+			t.Fatal("expected the pending request to fail with the connection")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the pending request was not failed")
+	}
+
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the next request to reconnect, got %v", err)
+	}
+}
+
+func TestPipeline_BrokenConnectionIsOpenedAgainWithoutReconnect(t *testing.T) {
+	server := startTestServer(t, pingHandler)
+	client, err := newTestTcpClient(t, server.address(), WithPipelining(8))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Fatalf("failed to ping: %v", err)
+	}
+
+	server.dropConnections()
+	if err := client.Ping(); err == nil {
+		t.Fatal("expected the ping on the broken connection to fail")
+	}
+	if err := client.Ping(); err != nil {
+		t.Fatalf("expected the next request to open the connection again, got %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// PoolSelection is the strategy used by IggyTcpPool to pick the connection for a request.
+type PoolSelection int
+
+const (
+	// LeastBusy picks the healthy connection with the fewest requests in flight.
+	LeastBusy PoolSelection = iota
+	// RoundRobin picks the healthy connections in turn.
+	RoundRobin
+)
+
+// PoolOptions configures IggyTcpPool.
+type PoolOptions struct {
+	Size      int
+	Selection PoolSelection
+}
+
+// WithPoolSize sets the number of connections opened by IggyTcpPool.
+func WithPoolSize(size int) Option {
+	return func(opts *Options) {
+		opts.Pool.Size = size
+	}
+}
+
+// WithPoolSelection sets the strategy used by IggyTcpPool to pick the connection for a request.
+func WithPoolSelection(selection PoolSelection) Option {
+	return func(opts *Options) {
+		opts.Pool.Selection = selection
+	}
+}
+
+// IggyTcpPool is a client spreading the requests over several connections, so that it can be shared by many goroutines.
+// Each connection has its own session: logging in and out is applied to all of them, while a consumer group is
+// joined on a single connection, which then serves the polls and the offsets of that group. The group has to be
+// referred to by the same identifiers in all of these calls.
+// The connections are checked every HeartbeatInterval, a broken one is skipped until it is opened again.
+type IggyTcpPool struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	members   []*poolMember
+	selection PoolSelection
+	next      atomic.Uint64
+	groupsMtx sync.Mutex
+	groups    map[string]*poolMember
+}
+
+type poolMember struct {
+	client   *IggyTcpClient
+	inFlight atomic.Int64
+	healthy  atomic.Bool
+}
+
+func NewIggyTcpPool(options ...Option) (*IggyTcpPool, error) {
+	opts := GetDefaultOptions()
+	for _, opt := range options {
+		if opt != nil {
+			opt(&opts)
+		}
+	}
+	if opts.Pool.Size <= 0 {
+		return nil, fmt.Errorf("invalid pool size: %d", opts.Pool.Size)
+	}
+
+	// The pool checks the connections itself instead of the heartbeat of each client.
+	options = append(options, func(opts *Options) {
+		opts.HeartbeatInterval = 0
+	})
+	ctx, cancel := context.WithCancel(opts.Ctx)
+	pool := &IggyTcpPool{
+		ctx:       ctx,
+		cancel:    cancel,
+		members:   make([]*poolMember, 0, opts.Pool.Size),
+		selection: opts.Pool.Selection,
+		groups:    make(map[string]*poolMember),
+	}
+	for range opts.Pool.Size {
+		client, err := NewIggyTcpClient(options...)
+		if err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+		member := &poolMember{client: client}
+		member.healthy.Store(true)
+		pool.members = append(pool.members, member)
+	}
+
+	if opts.HeartbeatInterval > 0 {
+		go pool.checkHealth(opts.HeartbeatInterval)
+	}
+
+	return pool, nil
+}
+
+// Close closes every connection, see IggyTcpClient.Close.
+func (p *IggyTcpPool) Close() error {
+	p.cancel()
+	errs := make([]error, 0, len(p.members))
+	for _, member := range p.members {
+		errs = append(errs, member.client.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// ServerVersion returns the first server version detected by the connections, see IggyTcpClient.ServerVersion.
+func (p *IggyTcpPool) ServerVersion() ServerVersion {
+	for _, member := range p.members {
+		if version := member.client.ServerVersion(); version.Known() {
+			return version
+		}
+	}
+	return ServerVersion{}
+}
+
+// checkHealth pings every connection, a broken one is closed and opened again by the next check.
+func (p *IggyTcpPool) checkHealth(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			for _, member := range p.members {
+				ctx, cancel := context.WithTimeout(p.ctx, interval)
+				err := member.client.PingCtx(ctx)
+				cancel()
+				if err == nil {
+					member.healthy.Store(true)
+				} else if isConnectionError(err) {
+					member.healthy.Store(false)
+					if err := member.client.lock(p.ctx); err == nil {
+						member.client.closeConnection()
+						member.client.unlock()
+					}
+				}
+			}
+		}
+	}
+}
+
+// pick returns the connection for the next request, falling back to the broken ones when none is healthy.
+func (p *IggyTcpPool) pick() *poolMember {
+	start := int(p.next.Add(1) % uint64(len(p.members)))
+	var picked *poolMember
+	for i := range p.members {
+		member := p.members[(start+i)%len(p.members)]
+		if !member.healthy.Load() {
+			continue
+		}
+		if p.selection == RoundRobin {
+			return member
+		}
+		if picked == nil || member.inFlight.Load() < picked.inFlight.Load() {
+			picked = member
+		}
+	}
+	if picked == nil {
+		picked = p.members[start]
+	}
+	return picked
+}
+
+func (p *IggyTcpPool) groupMember(streamId, topicId, groupId Identifier) *poolMember {
+	p.groupsMtx.Lock()
+	defer p.groupsMtx.Unlock()
+	return p.groups[groupKey(streamId, topicId, groupId)]
+}
+
+// consumerMember returns the connection which joined the group of the consumer, or any connection otherwise.
+func (p *IggyTcpPool) consumerMember(consumer Consumer, streamId, topicId Identifier) *poolMember {
+	if consumer.Kind == ConsumerKindGroup {
+		if member := p.groupMember(streamId, topicId, consumer.Id); member != nil {
+			return member
+		}
+	}
+	return p.pick()
+}
+
+func groupKey(streamId, topicId, groupId Identifier) string {
+	return fmt.Sprintf("%v/%v/%v", streamId.Value, topicId.Value, groupId.Value)
+}
+
+// call sends a request through the given connection, or through the one picked by the pool when it is nil.
+func call[T any](p *IggyTcpPool, member *poolMember, request func(client *IggyTcpClient) (T, error)) (T, error) {
+	if member == nil {
+		member = p.pick()
+	}
+	member.inFlight.Add(1)
+	defer member.inFlight.Add(-1)
+
+	result, err := request(member.client)
+	if err != nil && isConnectionError(err) && !isContextError(err) {
+		member.healthy.Store(false)
+	}
+	return result, err
+}
+
+func exec(p *IggyTcpPool, member *poolMember, request func(client *IggyTcpClient) error) error {
+	_, err := call(p, member, func(client *IggyTcpClient) (struct{}, error) {
+		return struct{}{}, request(client)
+	})
+	return err
+}
+
+// broadcast sends a request through every connection, returning the result of the first one.
+func broadcast[T any](p *IggyTcpPool, request func(client *IggyTcpClient) (T, error)) (T, error) {
+	var first T
+	for i, member := range p.members {
+		result, err := call(p, member, request)
+		if err != nil {
+			return first, err
+		}
+		if i == 0 {
+			first = result
+		}
+	}
+	return first, nil
+}
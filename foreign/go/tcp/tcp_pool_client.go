@@ -0,0 +1,524 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func (p *IggyTcpPool) GetStream(streamId Identifier) (*StreamDetails, error) {
+	return p.GetStreamCtx(p.ctx, streamId)
+}
+
+func (p *IggyTcpPool) GetStreamCtx(ctx context.Context, streamId Identifier) (*StreamDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*StreamDetails, error) {
+		return client.GetStreamCtx(ctx, streamId)
+	})
+}
+
+func (p *IggyTcpPool) GetStreams() ([]Stream, error) {
+	return p.GetStreamsCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) GetStreamsCtx(ctx context.Context) ([]Stream, error) {
+	return call(p, nil, func(client *IggyTcpClient) ([]Stream, error) {
+		return client.GetStreamsCtx(ctx)
+	})
+}
+
+func (p *IggyTcpPool) CreateStream(name string, streamId *uint32) (*StreamDetails, error) {
+	return p.CreateStreamCtx(p.ctx, name, streamId)
+}
+
+func (p *IggyTcpPool) CreateStreamCtx(ctx context.Context, name string, streamId *uint32) (*StreamDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*StreamDetails, error) {
+		return client.CreateStreamCtx(ctx, name, streamId)
+	})
+}
+
+func (p *IggyTcpPool) UpdateStream(streamId Identifier, name string) error {
+	return p.UpdateStreamCtx(p.ctx, streamId, name)
+}
+
+func (p *IggyTcpPool) UpdateStreamCtx(ctx context.Context, streamId Identifier, name string) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.UpdateStreamCtx(ctx, streamId, name)
+	})
+}
+
+func (p *IggyTcpPool) DeleteStream(id Identifier) error {
+	return p.DeleteStreamCtx(p.ctx, id)
+}
+
+func (p *IggyTcpPool) DeleteStreamCtx(ctx context.Context, id Identifier) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.DeleteStreamCtx(ctx, id)
+	})
+}
+
+func (p *IggyTcpPool) PurgeStream(id Identifier) error {
+	return p.PurgeStreamCtx(p.ctx, id)
+}
+
+func (p *IggyTcpPool) PurgeStreamCtx(ctx context.Context, id Identifier) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.PurgeStreamCtx(ctx, id)
+	})
+}
+
+func (p *IggyTcpPool) GetTopic(streamId, topicId Identifier) (*TopicDetails, error) {
+	return p.GetTopicCtx(p.ctx, streamId, topicId)
+}
+
+func (p *IggyTcpPool) GetTopicCtx(ctx context.Context, streamId, topicId Identifier) (*TopicDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*TopicDetails, error) {
+		return client.GetTopicCtx(ctx, streamId, topicId)
+	})
+}
+
+func (p *IggyTcpPool) GetTopics(streamId Identifier) ([]Topic, error) {
+	return p.GetTopicsCtx(p.ctx, streamId)
+}
+
+func (p *IggyTcpPool) GetTopicsCtx(ctx context.Context, streamId Identifier) ([]Topic, error) {
+	return call(p, nil, func(client *IggyTcpClient) ([]Topic, error) {
+		return client.GetTopicsCtx(ctx, streamId)
+	})
+}
+
+func (p *IggyTcpPool) CreateTopic(streamId Identifier, name string, partitionsCount uint32, config TopicConfig, topicId *int) (*TopicDetails, error) {
+	return p.CreateTopicCtx(p.ctx, streamId, name, partitionsCount, config, topicId)
+}
+
+func (p *IggyTcpPool) CreateTopicCtx(ctx context.Context, streamId Identifier, name string, partitionsCount uint32, config TopicConfig, topicId *int) (*TopicDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*TopicDetails, error) {
+		return client.CreateTopicCtx(ctx, streamId, name, partitionsCount, config, topicId)
+	})
+}
+
+func (p *IggyTcpPool) UpdateTopic(streamId Identifier, topicId Identifier, name string, config TopicConfig) error {
+	return p.UpdateTopicCtx(p.ctx, streamId, topicId, name, config)
+}
+
+func (p *IggyTcpPool) UpdateTopicCtx(ctx context.Context, streamId Identifier, topicId Identifier, name string, config TopicConfig) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.UpdateTopicCtx(ctx, streamId, topicId, name, config)
+	})
+}
+
+func (p *IggyTcpPool) DeleteTopic(streamId, topicId Identifier) error {
+	return p.DeleteTopicCtx(p.ctx, streamId, topicId)
+}
+
+func (p *IggyTcpPool) DeleteTopicCtx(ctx context.Context, streamId, topicId Identifier) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.DeleteTopicCtx(ctx, streamId, topicId)
+	})
+}
+
+func (p *IggyTcpPool) PurgeTopic(streamId, topicId Identifier) error {
+	return p.PurgeTopicCtx(p.ctx, streamId, topicId)
+}
+
+func (p *IggyTcpPool) PurgeTopicCtx(ctx context.Context, streamId, topicId Identifier) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.PurgeTopicCtx(ctx, streamId, topicId)
+	})
+}
+
+func (p *IggyTcpPool) SendMessages(streamId Identifier, topicId Identifier, partitioning Partitioning, messages []IggyMessage) error {
+	return p.SendMessagesCtx(p.ctx, streamId, topicId, partitioning, messages)
+}
+
+func (p *IggyTcpPool) SendMessagesCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitioning Partitioning, messages []IggyMessage) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.SendMessagesCtx(ctx, streamId, topicId, partitioning, messages)
+	})
+}
+
+func (p *IggyTcpPool) FlushUnsavedBuffer(streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error {
+	return p.FlushUnsavedBufferCtx(p.ctx, streamId, topicId, partitionId, fsync)
+}
+
+func (p *IggyTcpPool) FlushUnsavedBufferCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionId uint32, fsync bool) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.FlushUnsavedBufferCtx(ctx, streamId, topicId, partitionId, fsync)
+	})
+}
+
+func (p *IggyTcpPool) PollMessages(streamId Identifier, topicId Identifier, consumer Consumer, strategy PollingStrategy, count uint32, autoCommit bool, partitionId *uint32) (*PolledMessage, error) {
+	return p.PollMessagesCtx(p.ctx, streamId, topicId, consumer, strategy, count, autoCommit, partitionId)
+}
+
+func (p *IggyTcpPool) PollMessagesCtx(ctx context.Context, streamId Identifier, topicId Identifier, consumer Consumer, strategy PollingStrategy, count uint32, autoCommit bool, partitionId *uint32) (*PolledMessage, error) {
+	return call(p, p.consumerMember(consumer, streamId, topicId), func(client *IggyTcpClient) (*PolledMessage, error) {
+		return client.PollMessagesCtx(ctx, streamId, topicId, consumer, strategy, count, autoCommit, partitionId)
+	})
+}
+
+func (p *IggyTcpPool) PollMessageViews(streamId Identifier, topicId Identifier, consumer Consumer, strategy PollingStrategy, count uint32, autoCommit bool, partitionId *uint32) (*PolledMessageView, error) {
+	return p.PollMessageViewsCtx(p.ctx, streamId, topicId, consumer, strategy, count, autoCommit, partitionId)
+}
+
+func (p *IggyTcpPool) PollMessageViewsCtx(ctx context.Context, streamId Identifier, topicId Identifier, consumer Consumer, strategy PollingStrategy, count uint32, autoCommit bool, partitionId *uint32) (*PolledMessageView, error) {
+	return call(p, p.consumerMember(consumer, streamId, topicId), func(client *IggyTcpClient) (*PolledMessageView, error) {
+		return client.PollMessageViewsCtx(ctx, streamId, topicId, consumer, strategy, count, autoCommit, partitionId)
+	})
+}
+
+func (p *IggyTcpPool) StoreConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, offset uint64, partitionId *uint32) error {
+	return p.StoreConsumerOffsetCtx(p.ctx, consumer, streamId, topicId, offset, partitionId)
+}
+
+func (p *IggyTcpPool) StoreConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, offset uint64, partitionId *uint32) error {
+	return exec(p, p.consumerMember(consumer, streamId, topicId), func(client *IggyTcpClient) error {
+		return client.StoreConsumerOffsetCtx(ctx, consumer, streamId, topicId, offset, partitionId)
+	})
+}
+
+func (p *IggyTcpPool) GetConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) (*ConsumerOffsetInfo, error) {
+	return p.GetConsumerOffsetCtx(p.ctx, consumer, streamId, topicId, partitionId)
+}
+
+func (p *IggyTcpPool) GetConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) (*ConsumerOffsetInfo, error) {
+	return call(p, p.consumerMember(consumer, streamId, topicId), func(client *IggyTcpClient) (*ConsumerOffsetInfo, error) {
+		return client.GetConsumerOffsetCtx(ctx, consumer, streamId, topicId, partitionId)
+	})
+}
+
+func (p *IggyTcpPool) DeleteConsumerOffset(consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) error {
+	return p.DeleteConsumerOffsetCtx(p.ctx, consumer, streamId, topicId, partitionId)
+}
+
+func (p *IggyTcpPool) DeleteConsumerOffsetCtx(ctx context.Context, consumer Consumer, streamId Identifier, topicId Identifier, partitionId *uint32) error {
+	return exec(p, p.consumerMember(consumer, streamId, topicId), func(client *IggyTcpClient) error {
+		return client.DeleteConsumerOffsetCtx(ctx, consumer, streamId, topicId, partitionId)
+	})
+}
+
+func (p *IggyTcpPool) GetConsumerGroups(streamId Identifier, topicId Identifier) ([]ConsumerGroup, error) {
+	return p.GetConsumerGroupsCtx(p.ctx, streamId, topicId)
+}
+
+func (p *IggyTcpPool) GetConsumerGroupsCtx(ctx context.Context, streamId Identifier, topicId Identifier) ([]ConsumerGroup, error) {
+	return call(p, nil, func(client *IggyTcpClient) ([]ConsumerGroup, error) {
+		return client.GetConsumerGroupsCtx(ctx, streamId, topicId)
+	})
+}
+
+func (p *IggyTcpPool) GetConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) (*ConsumerGroupDetails, error) {
+	return p.GetConsumerGroupCtx(p.ctx, streamId, topicId, groupId)
+}
+
+func (p *IggyTcpPool) GetConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) (*ConsumerGroupDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*ConsumerGroupDetails, error) {
+		return client.GetConsumerGroupCtx(ctx, streamId, topicId, groupId)
+	})
+}
+
+func (p *IggyTcpPool) CreateConsumerGroup(streamId Identifier, topicId Identifier, name string, groupId *uint32) (*ConsumerGroupDetails, error) {
+	return p.CreateConsumerGroupCtx(p.ctx, streamId, topicId, name, groupId)
+}
+
+func (p *IggyTcpPool) CreateConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, name string, groupId *uint32) (*ConsumerGroupDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*ConsumerGroupDetails, error) {
+		return client.CreateConsumerGroupCtx(ctx, streamId, topicId, name, groupId)
+	})
+}
+
+func (p *IggyTcpPool) DeleteConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return p.DeleteConsumerGroupCtx(p.ctx, streamId, topicId, groupId)
+}
+
+func (p *IggyTcpPool) JoinConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return p.JoinConsumerGroupCtx(p.ctx, streamId, topicId, groupId)
+}
+
+func (p *IggyTcpPool) LeaveConsumerGroup(streamId Identifier, topicId Identifier, groupId Identifier) error {
+	return p.LeaveConsumerGroupCtx(p.ctx, streamId, topicId, groupId)
+}
+
+func (p *IggyTcpPool) CreatePartitions(streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return p.CreatePartitionsCtx(p.ctx, streamId, topicId, partitionsCount)
+}
+
+func (p *IggyTcpPool) CreatePartitionsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.CreatePartitionsCtx(ctx, streamId, topicId, partitionsCount)
+	})
+}
+
+func (p *IggyTcpPool) DeletePartitions(streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return p.DeletePartitionsCtx(p.ctx, streamId, topicId, partitionsCount)
+}
+
+func (p *IggyTcpPool) DeletePartitionsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionsCount uint32) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.DeletePartitionsCtx(ctx, streamId, topicId, partitionsCount)
+	})
+}
+
+func (p *IggyTcpPool) DeleteSegments(streamId Identifier, topicId Identifier, partitionId uint32, segmentsCount uint32) error {
+	return p.DeleteSegmentsCtx(p.ctx, streamId, topicId, partitionId, segmentsCount)
+}
+
+func (p *IggyTcpPool) DeleteSegmentsCtx(ctx context.Context, streamId Identifier, topicId Identifier, partitionId uint32, segmentsCount uint32) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.DeleteSegmentsCtx(ctx, streamId, topicId, partitionId, segmentsCount)
+	})
+}
+
+func (p *IggyTcpPool) GetUser(identifier Identifier) (*UserInfoDetails, error) {
+	return p.GetUserCtx(p.ctx, identifier)
+}
+
+func (p *IggyTcpPool) GetUserCtx(ctx context.Context, identifier Identifier) (*UserInfoDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*UserInfoDetails, error) {
+		return client.GetUserCtx(ctx, identifier)
+	})
+}
+
+func (p *IggyTcpPool) GetUsers() ([]UserInfo, error) {
+	return p.GetUsersCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) GetUsersCtx(ctx context.Context) ([]UserInfo, error) {
+	return call(p, nil, func(client *IggyTcpClient) ([]UserInfo, error) {
+		return client.GetUsersCtx(ctx)
+	})
+}
+
+func (p *IggyTcpPool) CreateUser(username string, password string, status UserStatus, permissions *Permissions) (*UserInfoDetails, error) {
+	return p.CreateUserCtx(p.ctx, username, password, status, permissions)
+}
+
+func (p *IggyTcpPool) CreateUserCtx(ctx context.Context, username string, password string, status UserStatus, permissions *Permissions) (*UserInfoDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*UserInfoDetails, error) {
+		return client.CreateUserCtx(ctx, username, password, status, permissions)
+	})
+}
+
+func (p *IggyTcpPool) UpdateUser(userID Identifier, username *string, status *UserStatus) error {
+	return p.UpdateUserCtx(p.ctx, userID, username, status)
+}
+
+func (p *IggyTcpPool) UpdateUserCtx(ctx context.Context, userID Identifier, username *string, status *UserStatus) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.UpdateUserCtx(ctx, userID, username, status)
+	})
+}
+
+func (p *IggyTcpPool) UpdatePermissions(userID Identifier, permissions *Permissions) error {
+	return p.UpdatePermissionsCtx(p.ctx, userID, permissions)
+}
+
+func (p *IggyTcpPool) UpdatePermissionsCtx(ctx context.Context, userID Identifier, permissions *Permissions) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.UpdatePermissionsCtx(ctx, userID, permissions)
+	})
+}
+
+func (p *IggyTcpPool) ChangePassword(userID Identifier, currentPassword string, newPassword string) error {
+	return p.ChangePasswordCtx(p.ctx, userID, currentPassword, newPassword)
+}
+
+func (p *IggyTcpPool) ChangePasswordCtx(ctx context.Context, userID Identifier, currentPassword string, newPassword string) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.ChangePasswordCtx(ctx, userID, currentPassword, newPassword)
+	})
+}
+
+func (p *IggyTcpPool) DeleteUser(identifier Identifier) error {
+	return p.DeleteUserCtx(p.ctx, identifier)
+}
+
+func (p *IggyTcpPool) DeleteUserCtx(ctx context.Context, identifier Identifier) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.DeleteUserCtx(ctx, identifier)
+	})
+}
+
+func (p *IggyTcpPool) CreatePersonalAccessToken(name string, expiry uint32) (*RawPersonalAccessToken, error) {
+	return p.CreatePersonalAccessTokenCtx(p.ctx, name, expiry)
+}
+
+func (p *IggyTcpPool) CreatePersonalAccessTokenCtx(ctx context.Context, name string, expiry uint32) (*RawPersonalAccessToken, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*RawPersonalAccessToken, error) {
+		return client.CreatePersonalAccessTokenCtx(ctx, name, expiry)
+	})
+}
+
+func (p *IggyTcpPool) DeletePersonalAccessToken(name string) error {
+	return p.DeletePersonalAccessTokenCtx(p.ctx, name)
+}
+
+func (p *IggyTcpPool) DeletePersonalAccessTokenCtx(ctx context.Context, name string) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.DeletePersonalAccessTokenCtx(ctx, name)
+	})
+}
+
+func (p *IggyTcpPool) GetPersonalAccessTokens() ([]PersonalAccessTokenInfo, error) {
+	return p.GetPersonalAccessTokensCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) GetPersonalAccessTokensCtx(ctx context.Context) ([]PersonalAccessTokenInfo, error) {
+	return call(p, nil, func(client *IggyTcpClient) ([]PersonalAccessTokenInfo, error) {
+		return client.GetPersonalAccessTokensCtx(ctx)
+	})
+}
+
+func (p *IggyTcpPool) LoginWithPersonalAccessToken(token string) (*IdentityInfo, error) {
+	return p.LoginWithPersonalAccessTokenCtx(p.ctx, token)
+}
+
+func (p *IggyTcpPool) LoginWithPersonalAccessTokenCtx(ctx context.Context, token string) (*IdentityInfo, error) {
+	return broadcast(p, func(client *IggyTcpClient) (*IdentityInfo, error) {
+		return client.LoginWithPersonalAccessTokenCtx(ctx, token)
+	})
+}
+
+func (p *IggyTcpPool) LoginUser(username string, password string) (*IdentityInfo, error) {
+	return p.LoginUserCtx(p.ctx, username, password)
+}
+
+func (p *IggyTcpPool) LoginUserCtx(ctx context.Context, username string, password string) (*IdentityInfo, error) {
+	return broadcast(p, func(client *IggyTcpClient) (*IdentityInfo, error) {
+		return client.LoginUserCtx(ctx, username, password)
+	})
+}
+
+func (p *IggyTcpPool) LogoutUser() error {
+	return p.LogoutUserCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) LogoutUserCtx(ctx context.Context) error {
+	_, err := broadcast(p, func(client *IggyTcpClient) (struct{}, error) {
+		return struct{}{}, client.LogoutUserCtx(ctx)
+	})
+	return err
+}
+
+func (p *IggyTcpPool) GetStats() (*Stats, error) {
+	return p.GetStatsCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) GetStatsCtx(ctx context.Context) (*Stats, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*Stats, error) {
+		return client.GetStatsCtx(ctx)
+	})
+}
+
+func (p *IggyTcpPool) GetSnapshot(compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error) {
+	return p.GetSnapshotCtx(p.ctx, compression, snapshotTypes)
+}
+
+func (p *IggyTcpPool) GetSnapshotCtx(ctx context.Context, compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error) {
+	return call(p, nil, func(client *IggyTcpClient) (Snapshot, error) {
+		return client.GetSnapshotCtx(ctx, compression, snapshotTypes)
+	})
+}
+
+func (p *IggyTcpPool) Ping() error {
+	return p.PingCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) PingCtx(ctx context.Context) error {
+	return exec(p, nil, func(client *IggyTcpClient) error {
+		return client.PingCtx(ctx)
+	})
+}
+
+func (p *IggyTcpPool) GetClients() ([]ClientInfo, error) {
+	return p.GetClientsCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) GetClientsCtx(ctx context.Context) ([]ClientInfo, error) {
+	return call(p, nil, func(client *IggyTcpClient) ([]ClientInfo, error) {
+		return client.GetClientsCtx(ctx)
+	})
+}
+
+// GetMe returns the info of one of the connections.
+func (p *IggyTcpPool) GetMe() (*ClientInfoDetails, error) {
+	return p.GetMeCtx(p.ctx)
+}
+
+func (p *IggyTcpPool) GetMeCtx(ctx context.Context) (*ClientInfoDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*ClientInfoDetails, error) {
+		return client.GetMeCtx(ctx)
+	})
+}
+
+func (p *IggyTcpPool) GetClient(clientId int) (*ClientInfoDetails, error) {
+	return p.GetClientCtx(p.ctx, clientId)
+}
+
+func (p *IggyTcpPool) GetClientCtx(ctx context.Context, clientId int) (*ClientInfoDetails, error) {
+	return call(p, nil, func(client *IggyTcpClient) (*ClientInfoDetails, error) {
+		return client.GetClientCtx(ctx, clientId)
+	})
+}
+
+func (p *IggyTcpPool) JoinConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
+	member := p.groupMember(streamId, topicId, groupId)
+	if member == nil {
+		member = p.pick()
+	}
+	err := exec(p, member, func(client *IggyTcpClient) error {
+		return client.JoinConsumerGroupCtx(ctx, streamId, topicId, groupId)
+	})
+	if err != nil {
+		return err
+	}
+
+	p.groupsMtx.Lock()
+	defer p.groupsMtx.Unlock()
+	p.groups[groupKey(streamId, topicId, groupId)] = member
+	return nil
+}
+
+func (p *IggyTcpPool) LeaveConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
+	err := exec(p, p.groupMember(streamId, topicId, groupId), func(client *IggyTcpClient) error {
+		return client.LeaveConsumerGroupCtx(ctx, streamId, topicId, groupId)
+	})
+	if err != nil {
+		return err
+	}
+	p.forgetGroup(streamId, topicId, groupId)
+	return nil
+}
+
+func (p *IggyTcpPool) DeleteConsumerGroupCtx(ctx context.Context, streamId Identifier, topicId Identifier, groupId Identifier) error {
+	err := exec(p, p.groupMember(streamId, topicId, groupId), func(client *IggyTcpClient) error {
+		return client.DeleteConsumerGroupCtx(ctx, streamId, topicId, groupId)
+	})
+	if err != nil {
+		return err
+	}
+	p.forgetGroup(streamId, topicId, groupId)
+	return nil
+}
+
+func (p *IggyTcpPool) forgetGroup(streamId, topicId, groupId Identifier) {
+	p.groupsMtx.Lock()
+	defer p.groupsMtx.Unlock()
+	delete(p.groups, groupKey(streamId, topicId, groupId))
+}
@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func newTestTcpPool(t *testing.T, address string, options ...Option) *IggyTcpPool {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	options = append([]Option{WithServerAddress(address), WithContext(ctx)}, options...)
+	pool, err := NewIggyTcpPool(options...)
+	if err != nil {
+		t.Fatalf("failed to create the pool: %v", err)
+	}
+	return pool
+}
+
+func countCommands(commands []iggcon.CommandCode, command iggcon.CommandCode) int {
+	count := 0
+	for _, received := range commands {
+		if received == command {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPool_LoginAppliesToAllConnections(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	pool := newTestTcpPool(t, server.address(), WithPoolSize(3))
+
+	if _, err := pool.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	if count := countCommands(recorder.received(), iggcon.LoginUserCode); count != 3 {
+		t.Fatalf("expected each of the 3 connections to login, got %d logins", count)
+	}
+}
+
+func TestPool_SlowRequestDoesNotBlockOthers(t *testing.T) {
+	handler, received, unblock := blockingHandler()
+	server := startTestServer(t, handler)
+	t.Cleanup(unblock)
+	pool := newTestTcpPool(t, server.address(), WithPoolSize(2))
+
+	go func() {
+		_ = pool.Ping()
+	}()
+	<-received
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := pool.PingCtx(ctx); err != nil {
+		t.Fatalf("expected the ping to be sent through the idle connection, got %v", err)
+	}
+}
+
+func TestPool_ConsumerGroupIsServedByJoiningConnection(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	pool := newTestTcpPool(t, server.address(), WithPoolSize(3), WithPoolSelection(RoundRobin))
+
+	stream, topic, group := iggcon.NewIdentifier(1), iggcon.NewIdentifier(2), iggcon.NewIdentifier(3)
+	if err := pool.JoinConsumerGroup(stream, topic, group); err != nil {
+		t.Fatalf("failed to join the consumer group: %v", err)
+	}
+	joined := pool.groupMember(stream, topic, group)
+	if joined == nil {
+		t.Fatal("expected the consumer group to be bound to the joining connection")
+	}
+	consumer := iggcon.Consumer{Kind: iggcon.ConsumerKindGroup, Id: group}
+	for range 3 {
+		if member := pool.consumerMember(consumer, stream, topic); member != joined {
+			t.Fatal("expected the consumer group to be served by the joining connection")
+		}
+	}
+
+	if err := pool.LeaveConsumerGroup(stream, topic, group); err != nil {
+		t.Fatalf("failed to leave the consumer group: %v", err)
+	}
+	if pool.groupMember(stream, topic, group) != nil {
+		t.Fatal("expected the consumer group to be released after leaving it")
+	}
+}
+
+func TestPool_RestoresBrokenConnections(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	pool := newTestTcpPool(t, server.address(), WithPoolSize(2), func(opts *Options) {
+		opts.HeartbeatInterval = 20 * time.Millisecond
+	})
+	if _, err := pool.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	recorder.reset()
+	server.dropConnections()
+	deadline := time.Now().Add(5 * time.Second)
+	for countCommands(recorder.received(), iggcon.LoginUserCode) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected both sessions to be restored, got %v", recorder.received())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !slices.ContainsFunc(pool.members, func(member *poolMember) bool { return member.healthy.Load() }) {
+		t.Fatal("expected the restored connections to be healthy")
+	}
+	if err := pool.Ping(); err != nil {
+		t.Fatalf("ping after the restore failed: %v", err)
+	}
+}
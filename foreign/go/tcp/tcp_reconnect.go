@@ -0,0 +1,225 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+// ReconnectOptions configures how the client restores a dropped connection.
+// When enabled, a request failing with a connection error closes the connection and re-dials the server
+// with an exponential backoff. The session is then restored by logging in again with the last credentials
+// or personal access token, and by re-joining the consumer groups joined through JoinConsumerGroup.
+// The failed request itself is not retried and still returns its error, unless a retry policy set with WithRetryPolicy
+// sends it again on the restored connection.
+type ReconnectOptions struct {
+	Enabled bool
+	// MaxRetries is the maximum number of dial attempts per reconnect, 0 means unlimited.
+	MaxRetries int
+	// Interval is the delay before the second attempt, doubled after each failed attempt up to MaxInterval.
+	Interval    time.Duration
+	MaxInterval time.Duration
+	// OnReconnect is called after each reconnect with nil on success, or with the error which made it fail.
+	// It is only called with the reconnect enabled, the moves between the server addresses being reported
+	// by the Reconnecting and Connected events otherwise.
+	OnReconnect func(err error)
+}
+
+// WithReconnect enables the automatic reconnect with the default retry settings.
+func WithReconnect() Option {
+	return func(opts *Options) {
+		opts.Reconnect.Enabled = true
+	}
+}
+
+// WithReconnectMaxRetries sets the maximum number of dial attempts per reconnect, 0 means unlimited.
+func WithReconnectMaxRetries(maxRetries int) Option {
+	return func(opts *Options) {
+		opts.Reconnect.Enabled = true
+		opts.Reconnect.MaxRetries = maxRetries
+	}
+}
+
+// WithReconnectInterval sets the initial and the maximum delay between the dial attempts.
+func WithReconnectInterval(interval, maxInterval time.Duration) Option {
+	return func(opts *Options) {
+		opts.Reconnect.Enabled = true
+		opts.Reconnect.Interval = interval
+		opts.Reconnect.MaxInterval = maxInterval
+	}
+}
+
+// WithReconnectCallback sets the function reporting the outcome of each reconnect.
+func WithReconnectCallback(onReconnect func(err error)) Option {
+	return func(opts *Options) {
+		opts.Reconnect.Enabled = true
+		opts.Reconnect.OnReconnect = onReconnect
+	}
+}
+
+// session holds the state which has to be restored on a new connection.
+type session struct {
+	loginCommand CommandCode
+	loginMessage []byte
+	// joinedGroups holds the serialized identifiers of the joined consumer groups, keyed by their string form.
+	joinedGroups map[string][]byte
+}
+
+func (tms *IggyTcpClient) setLogin(command CommandCode, message []byte) {
+	tms.sessionMtx.Lock()
+	tms.session.loginCommand = command
+	tms.session.loginMessage = message
+	tms.sessionMtx.Unlock()
+	tms.emit(Authenticated, nil)
+}
+
+func (tms *IggyTcpClient) clearSession() {
+	tms.sessionMtx.Lock()
+	tms.session = session{}
+	tms.sessionMtx.Unlock()
+	tms.emit(LoggedOut, nil)
+}
+
+func (tms *IggyTcpClient) addJoinedGroup(message []byte) {
+	tms.sessionMtx.Lock()
+	defer tms.sessionMtx.Unlock()
+	if tms.session.joinedGroups == nil {
+		tms.session.joinedGroups = make(map[string][]byte)
+	}
+	tms.session.joinedGroups[string(message)] = message
+}
+
+func (tms *IggyTcpClient) removeJoinedGroup(message []byte) {
+	tms.sessionMtx.Lock()
+	defer tms.sessionMtx.Unlock()
+	delete(tms.session.joinedGroups, string(message))
+}
+
+// restoreConnection replaces the connection and restores the session, the caller must hold the connection lock.
+func (tms *IggyTcpClient) restoreConnection(ctx context.Context) error {
+	err := tms.redial(ctx)
+	if err == nil {
+		err = tms.restoreSession(ctx)
+		if err != nil && isConnectionError(err) {
+			tms.closeConnection()
+		}
+	}
+	if tms.reconnect.Enabled && tms.reconnect.OnReconnect != nil {
+		tms.reconnect.OnReconnect(err)
+	}
+
+	return err
+}
+
+// redial opens a new connection. Without reconnect enabled, it is done in a single attempt.
+func (tms *IggyTcpClient) redial(ctx context.Context) error {
+	tms.closeConnection()
+
+	interval := tms.reconnect.Interval
+	var err error
+	for attempt := 1; ; attempt++ {
+		tms.emit(Reconnecting, err)
+		var conn net.Conn
+		conn, err = tms.failover.dial(ctx)
+		if err == nil {
+			tms.setConnected(conn)
+			return nil
+		}
+		if !tms.reconnect.Enabled || (tms.reconnect.MaxRetries > 0 && attempt >= tms.reconnect.MaxRetries) {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		interval *= 2
+		if tms.reconnect.MaxInterval > 0 && interval > tms.reconnect.MaxInterval {
+			interval = tms.reconnect.MaxInterval
+		}
+	}
+}
+
+func (tms *IggyTcpClient) restoreSession(ctx context.Context) error {
+	tms.sessionMtx.Lock()
+	loginCommand, loginMessage := tms.session.loginCommand, tms.session.loginMessage
+	joinedGroups := make([][]byte, 0, len(tms.session.joinedGroups))
+	for _, message := range tms.session.joinedGroups {
+		joinedGroups = append(joinedGroups, message)
+	}
+	tms.sessionMtx.Unlock()
+
+	if loginMessage == nil {
+		return nil
+	}
+	if _, err := tms.roundTrip(ctx, newFrame(loginMessage, loginCommand)); err != nil {
+		return err
+	}
+	tms.emit(Authenticated, nil)
+	// The connection may have moved to another server.
+	if buffer, err := tms.roundTrip(ctx, newFrame([]byte{}, GetStatsCode)); err == nil {
+		tms.setServerVersion(buffer)
+	}
+	for _, message := range joinedGroups {
+		if _, err := tms.roundTrip(ctx, newFrame(message, JoinGroupCode)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tms *IggyTcpClient) closeConnection() {
+	if tms.conn != nil {
+		tms.disconnected(nil)
+	}
+	if tms.pipeline != nil {
+		tms.pipeline.fail(net.ErrClosed)
+		tms.pipeline = nil
+	}
+	if tms.conn != nil {
+		_ = tms.conn.Close()
+		tms.conn = nil
+	}
+}
+
+// isConnectionError reports whether the error comes from the connection rather than from the server.
+func isConnectionError(err error) bool {
+	var iggyErr *ierror.IggyError
+	return !errors.As(err, &iggyErr)
+}
+
+// isUnauthenticated reports whether the server rejected the request because the session is not authenticated.
+func isUnauthenticated(err error) bool {
+	var iggyErr *ierror.IggyError
+	return errors.As(err, &iggyErr) && iggyErr.Code == ierror.Unauthenticated.Code
+}
+
+// isContextError reports whether the request was interrupted by its context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
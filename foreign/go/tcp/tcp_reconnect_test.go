@@ -0,0 +1,146 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+// commandRecorder is a handler which records the received commands and answers every request successfully.
+type commandRecorder struct {
+	mtx      sync.Mutex
+	commands []iggcon.CommandCode
+}
+
+func (r *commandRecorder) handle(command iggcon.CommandCode, _ []byte) (uint32, []byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.commands = append(r.commands, command)
+	if command == iggcon.LoginUserCode || command == iggcon.LoginWithAccessTokenCode {
+		return 0, []byte{1, 0, 0, 0}
+	}
+	return 0, nil
+}
+
+func (r *commandRecorder) reset() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.commands = nil
+}
+
+func (r *commandRecorder) received() []iggcon.CommandCode {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return slices.Clone(r.commands)
+}
+
+func TestReconnect_RestoresSession(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := startTestServer(t, recorder.handle)
+	outcomes := make(chan error, 10)
+	client, err := newTestTcpClient(t, server.address(),
+		WithReconnectInterval(10*time.Millisecond, 50*time.Millisecond),
+		WithReconnectCallback(func(err error) { outcomes <- err }),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	stream, topic := iggcon.NewIdentifier(1), iggcon.NewIdentifier(2)
+	for _, group := range []int{3, 4} {
+		if err := client.JoinConsumerGroup(stream, topic, iggcon.NewIdentifier(group)); err != nil {
+			t.Fatalf("failed to join the consumer group: %v", err)
+		}
+	}
+	if err := client.LeaveConsumerGroup(stream, topic, iggcon.NewIdentifier(4)); err != nil {
+		t.Fatalf("failed to leave the consumer group: %v", err)
+	}
+
+	server.dropConnections()
+	recorder.reset()
+	if err := client.Ping(); err == nil {
+		t.Fatal("expected the ping on the dropped connection to fail")
+	}
+	select {
+	case err := <-outcomes:
+		if err != nil {
+			t.Fatalf("expected the reconnect to succeed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the reconnect was not reported")
+	}
+
+	expected := []iggcon.CommandCode{iggcon.LoginUserCode, iggcon.GetStatsCode, iggcon.JoinGroupCode}
+	if commands := recorder.received(); !slices.Equal(commands, expected) {
+		t.Fatalf("expected the session to be restored with %v, got %v", expected, commands)
+	}
+	if err := client.Ping(); err != nil {
+		t.Fatalf("ping after the reconnect failed: %v", err)
+	}
+}
+
+func TestReconnect_ReportsFailureAndRetriesOnNextCall(t *testing.T) {
+	server := startTestServer(t, pingHandler)
+	outcomes := make(chan error, 10)
+	client, err := newTestTcpClient(t, server.address(),
+		WithReconnectMaxRetries(2),
+		WithReconnectInterval(time.Millisecond, time.Millisecond),
+		WithReconnectCallback(func(err error) { outcomes <- err }),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	server.close()
+	for range 2 {
+		if err := client.Ping(); err == nil {
+			t.Fatal("expected the ping to fail while the server is down")
+		}
+		select {
+		case err := <-outcomes:
+			if err == nil {
+				t.Fatal("expected the reconnect to fail while the server is down")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("the reconnect was not reported")
+		}
+	}
+}
+
+func TestReconnect_Disabled(t *testing.T) {
+	server := startTestServer(t, pingHandler)
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	server.dropConnections()
+	for range 2 {
+		if err := client.Ping(); err == nil {
+			t.Fatal("expected the ping to fail without the reconnect")
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+func testRetryPolicy() iggcon.RetryPolicy {
+	policy := iggcon.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	return policy
+}
+
+// failingServer starts a server failing the first request of the command, either by dropping the connection or with
+// the given status.
+func failingServer(t *testing.T, command iggcon.CommandCode, status uint32) (*testServer, *atomic.Int32) {
+	var server atomic.Pointer[testServer]
+	attempts := &atomic.Int32{}
+	server.Store(startTestServer(t, func(received iggcon.CommandCode, _ []byte) (uint32, []byte) {
+		if received != command || attempts.Add(1) > 1 {
+			return 0, nil
+		}
+		if status == 0 {
+			server.Load().dropConnections()
+		}
+		return status, nil
+	}))
+	return server.Load(), attempts
+}
+
+func TestRetryPolicy_RetriesIdempotentCommandAfterReconnect(t *testing.T) {
+	server, attempts := failingServer(t, iggcon.GetStreamsCode, 0)
+	client, err := newTestTcpClient(t, server.address(),
+		WithReconnectInterval(time.Millisecond, 10*time.Millisecond),
+		WithRetryPolicy(testRetryPolicy()),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if _, err := client.GetStreams(); err != nil {
+		t.Fatalf("expected the command to be retried on the restored connection, got %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryPolicy_DoesNotResendNonIdempotentCommandAfterConnectionError(t *testing.T) {
+	server, attempts := failingServer(t, iggcon.DeleteStreamCode, 0)
+	client, err := newTestTcpClient(t, server.address(), WithReconnect(), WithRetryPolicy(testRetryPolicy()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if err := client.DeleteStream(iggcon.NewIdentifier(1)); err == nil || !ierror.IsRetryable(err) {
+		t.Fatalf("expected the connection error, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts.Load())
+	}
+}
+
+func TestRetryPolicy_RetriesNonIdempotentCommandRejectedByServer(t *testing.T) {
+	server, attempts := failingServer(t, iggcon.DeleteStreamCode, uint32(ierror.NotConnected.Code))
+	client, err := newTestTcpClient(t, server.address(), WithRetryPolicy(testRetryPolicy()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if err := client.DeleteStream(iggcon.NewIdentifier(1)); err != nil {
+		t.Fatalf("expected the command to be retried, got %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryPolicy_StopsAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	server := startTestServer(t, func(command iggcon.CommandCode, _ []byte) (uint32, []byte) {
+		if command == iggcon.PingCode {
+			attempts.Add(1)
+			return uint32(ierror.NotConnected.Code), nil
+		}
+		return 0, nil
+	})
+	client, err := newTestTcpClient(t, server.address(), WithRetryPolicy(testRetryPolicy()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if err := client.Ping(); !ierror.IsRetryable(err) {
+		t.Fatalf("expected the last error to be returned, got %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+}
@@ -18,37 +18,60 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 
 	. "github.com/apache/iggy/foreign/go/contracts"
 )
 
 func (tms *IggyTcpClient) LoginUser(username string, password string) (*IdentityInfo, error) {
+	return tms.LoginUserCtx(tms.ctx, username, password)
+}
+
+func (tms *IggyTcpClient) LoginUserCtx(ctx context.Context, username string, password string) (*IdentityInfo, error) {
 	serializedRequest := binaryserialization.TcpLogInRequest{
 		Username: username,
 		Password: password,
 	}
-	buffer, err := tms.sendAndFetchResponse(serializedRequest.Serialize(), LoginUserCode)
+	message := serializedRequest.Serialize()
+	buffer, err := tms.sendAndFetchResponse(ctx, message, LoginUserCode)
 	if err != nil {
 		return nil, err
 	}
+	tms.setLogin(LoginUserCode, message)
+	tms.detectServerVersion(ctx)
 
 	return binaryserialization.DeserializeLogInResponse(buffer), nil
 }
 
 func (tms *IggyTcpClient) LoginWithPersonalAccessToken(token string) (*IdentityInfo, error) {
+	return tms.LoginWithPersonalAccessTokenCtx(tms.ctx, token)
+}
+
+func (tms *IggyTcpClient) LoginWithPersonalAccessTokenCtx(ctx context.Context, token string) (*IdentityInfo, error) {
 	message := binaryserialization.SerializeLoginWithPersonalAccessToken(LoginWithPersonalAccessTokenRequest{
 		Token: token,
 	})
-	buffer, err := tms.sendAndFetchResponse(message, LoginWithAccessTokenCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, LoginWithAccessTokenCode)
 	if err != nil {
 		return nil, err
 	}
+	tms.setLogin(LoginWithAccessTokenCode, message)
+	tms.detectServerVersion(ctx)
 
 	return binaryserialization.DeserializeLogInResponse(buffer), nil
 }
 
 func (tms *IggyTcpClient) LogoutUser() error {
-	_, err := tms.sendAndFetchResponse([]byte{}, LogoutUserCode)
-	return err
+	return tms.LogoutUserCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) LogoutUserCtx(ctx context.Context) error {
+	_, err := tms.sendAndFetchResponse(ctx, []byte{}, LogoutUserCode)
+	if err != nil {
+		return err
+	}
+	tms.clearSession()
+	return nil
 }
@@ -18,23 +18,33 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
 func (tms *IggyTcpClient) GetStreams() ([]Stream, error) {
-	buffer, err := tms.sendAndFetchResponse([]byte{}, GetStreamsCode)
+	return tms.GetStreamsCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) GetStreamsCtx(ctx context.Context) ([]Stream, error) {
+	buffer, err := tms.sendAndFetchResponse(ctx, []byte{}, GetStreamsCode)
 	if err != nil {
 		return nil, err
 	}
 
-	return binaryserialization.DeserializeStreams(buffer), nil
+	return binaryserialization.DeserializeStreams(buffer)
 }
 
 func (tms *IggyTcpClient) GetStream(streamId Identifier) (*StreamDetails, error) {
+	return tms.GetStreamCtx(tms.ctx, streamId)
+}
+
+func (tms *IggyTcpClient) GetStreamCtx(ctx context.Context, streamId Identifier) (*StreamDetails, error) {
 	message := binaryserialization.SerializeIdentifier(streamId)
-	buffer, err := tms.sendAndFetchResponse(message, GetStreamCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetStreamCode)
 	if err != nil {
 		return nil, err
 	}
@@ -42,35 +52,54 @@ func (tms *IggyTcpClient) GetStream(streamId Identifier) (*StreamDetails, error)
 		return nil, ierror.StreamIdNotFound
 	}
 
-	stream := binaryserialization.DeserializeStream(buffer)
-	return stream, nil
+	return binaryserialization.DeserializeStream(buffer)
 }
 
 func (tms *IggyTcpClient) CreateStream(name string, streamId *uint32) (*StreamDetails, error) {
+	return tms.CreateStreamCtx(tms.ctx, name, streamId)
+}
+
+func (tms *IggyTcpClient) CreateStreamCtx(ctx context.Context, name string, streamId *uint32) (*StreamDetails, error) {
 	if MaxStringLength < len(name) {
 		return nil, ierror.TextTooLong("stream_name")
 	}
 	serializedRequest := binaryserialization.TcpCreateStreamRequest{Name: name, StreamId: streamId}
-	buffer, err := tms.sendAndFetchResponse(serializedRequest.Serialize(), CreateStreamCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, serializedRequest.Serialize(), CreateStreamCode)
 	if err != nil {
 		return nil, err
 	}
-	stream := binaryserialization.DeserializeStream(buffer)
-
-	return stream, err
+	return binaryserialization.DeserializeStream(buffer)
 }
 
 func (tms *IggyTcpClient) UpdateStream(streamId Identifier, name string) error {
+	return tms.UpdateStreamCtx(tms.ctx, streamId, name)
+}
+
+func (tms *IggyTcpClient) UpdateStreamCtx(ctx context.Context, streamId Identifier, name string) error {
 	if MaxStringLength <= len(name) {
 		return ierror.TextTooLong("stream_name")
 	}
 	serializedRequest := binaryserialization.TcpUpdateStreamRequest{StreamId: streamId, Name: name}
-	_, err := tms.sendAndFetchResponse(serializedRequest.Serialize(), UpdateStreamCode)
+	_, err := tms.sendAndFetchResponse(ctx, serializedRequest.Serialize(), UpdateStreamCode)
 	return err
 }
 
 func (tms *IggyTcpClient) DeleteStream(id Identifier) error {
+	return tms.DeleteStreamCtx(tms.ctx, id)
+}
+
+func (tms *IggyTcpClient) DeleteStreamCtx(ctx context.Context, id Identifier) error {
+	message := binaryserialization.SerializeIdentifier(id)
+	_, err := tms.sendAndFetchResponse(ctx, message, DeleteStreamCode)
+	return err
+}
+
+func (tms *IggyTcpClient) PurgeStream(id Identifier) error {
+	return tms.PurgeStreamCtx(tms.ctx, id)
+}
+
+func (tms *IggyTcpClient) PurgeStreamCtx(ctx context.Context, id Identifier) error {
 	message := binaryserialization.SerializeIdentifier(id)
-	_, err := tms.sendAndFetchResponse(message, DeleteStreamCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, PurgeStreamCode)
 	return err
 }
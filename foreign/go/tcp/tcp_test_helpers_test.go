@@ -0,0 +1,233 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+)
+
+func newTestTcpClient(t *testing.T, address string, options ...Option) (*IggyTcpClient, error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	options = append([]Option{WithServerAddress(address), WithContext(ctx)}, options...)
+	return NewIggyTcpClient(options...)
+}
+
+// commandHandler returns the status and the payload of the response for a single request.
+type commandHandler func(command iggcon.CommandCode, payload []byte) (uint32, []byte)
+
+func pingHandler(iggcon.CommandCode, []byte) (uint32, []byte) {
+	return 0, nil
+}
+
+// testServer is a minimal implementation of the binary protocol used to exercise the client without a running Iggy server.
+type testServer struct {
+	listener net.Listener
+	handler  commandHandler
+	mtx      sync.Mutex
+	conns    []net.Conn
+	wg       sync.WaitGroup
+}
+
+func startTestServer(t *testing.T, handler commandHandler) *testServer {
+	t.Helper()
+	return startTestServerAt(t, "127.0.0.1:0", handler)
+}
+
+// startTestServerAt starts the server on the given address, e.g. to bring back a server which was closed.
+func startTestServerAt(t *testing.T, address string, handler commandHandler) *testServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	return serve(t, listener, handler)
+}
+
+func startTestTLSServer(t *testing.T, config *tls.Config, handler commandHandler) *testServer {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatalf("failed to start test TLS server: %v", err)
+	}
+	return serve(t, listener, handler)
+}
+
+func serve(t *testing.T, listener net.Listener, handler commandHandler) *testServer {
+	server := &testServer{listener: listener, handler: handler}
+	server.wg.Add(1)
+	go func() {
+		defer server.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mtx.Lock()
+			server.conns = append(server.conns, conn)
+			server.mtx.Unlock()
+			server.wg.Add(1)
+			go func() {
+				defer server.wg.Done()
+				server.handle(conn)
+			}()
+		}
+	}()
+	t.Cleanup(server.close)
+	return server
+}
+
+func (s *testServer) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		length := binary.LittleEndian.Uint32(header[0:4])
+		command := iggcon.CommandCode(binary.LittleEndian.Uint32(header[4:8]))
+		payload := make([]byte, length-4)
+		if _, err := io.ReadFull(conn, payload); err != nil {
+			return
+		}
+
+		status, response := s.handler(command, payload)
+		frame := make([]byte, 8+len(response))
+		binary.LittleEndian.PutUint32(frame[0:4], status)
+		binary.LittleEndian.PutUint32(frame[4:8], uint32(len(response)))
+		copy(frame[8:], response)
+		if _, err := conn.Write(frame); err != nil {
+			return
+		}
+	}
+}
+
+// dropConnections closes every accepted connection while the listener keeps accepting new ones.
+func (s *testServer) dropConnections() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *testServer) close() {
+	_ = s.listener.Close()
+	s.dropConnections()
+	s.wg.Wait()
+}
+
+// testCertificate is a certificate with its private key, issued either by itself or by another testCertificate.
+type testCertificate struct {
+	certificate *x509.Certificate
+	tlsCert     tls.Certificate
+}
+
+func createTestCA(t *testing.T) *testCertificate {
+	t.Helper()
+	return createTestCertificate(t, nil, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Iggy Test CA"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	})
+}
+
+func createTestServerCertificate(t *testing.T, ca *testCertificate, dnsNames ...string) *testCertificate {
+	t.Helper()
+	return createTestCertificate(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "Iggy Test Server"},
+		DNSNames:    dnsNames,
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+}
+
+func createTestClientCertificate(t *testing.T, ca *testCertificate) *testCertificate {
+	t.Helper()
+	return createTestCertificate(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "Iggy Test Client"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+}
+
+func createTestCertificate(t *testing.T, issuer *testCertificate, template *x509.Certificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("failed to generate serial number: %v", err)
+	}
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parent, signer := template, any(key)
+	if issuer != nil {
+		parent, signer = issuer.certificate, issuer.tlsCert.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return &testCertificate{
+		certificate: certificate,
+		tlsCert: tls.Certificate{
+			Certificate: [][]byte{der},
+			PrivateKey:  key,
+			Leaf:        certificate,
+		},
+	}
+}
+
+func (c *testCertificate) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.certificate)
+	return pool
+}
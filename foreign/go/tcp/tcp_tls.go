@@ -0,0 +1,145 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+)
+
+// TLSOptions configures TLS for the TCP transport. TLS is disabled unless Enabled is set,
+// which every WithTLS* option does implicitly.
+type TLSOptions struct {
+	Enabled bool
+	// CAFile is a PEM file with the certificates used to verify the server, in addition to RootCAs.
+	CAFile string
+	// RootCAs is the pool used to verify the server. When both RootCAs and CAFile are empty the system pool is used.
+	RootCAs *x509.CertPool
+	// CertFile and KeyFile are the PEM encoded client certificate and private key used for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// Certificates are the client certificates used for mutual TLS, in addition to CertFile and KeyFile.
+	Certificates []tls.Certificate
+	// ServerName overrides the name used to verify the server certificate. Defaults to the host of the server address.
+	ServerName string
+	// InsecureSkipVerify disables verification of the server certificate. Use it for development only.
+	InsecureSkipVerify bool
+}
+
+// WithTLS enables TLS using the system certificate pool to verify the server.
+func WithTLS() Option {
+	return func(opts *Options) {
+		opts.TLS.Enabled = true
+	}
+}
+
+// WithTLSCAFile enables TLS and verifies the server against the certificates in the given PEM file.
+func WithTLSCAFile(caFile string) Option {
+	return func(opts *Options) {
+		opts.TLS.Enabled = true
+		opts.TLS.CAFile = caFile
+	}
+}
+
+// WithTLSRootCAs enables TLS and verifies the server against the given certificate pool.
+func WithTLSRootCAs(pool *x509.CertPool) Option {
+	return func(opts *Options) {
+		opts.TLS.Enabled = true
+		opts.TLS.RootCAs = pool
+	}
+}
+
+// WithTLSClientCertFiles enables mutual TLS using the PEM encoded certificate and private key files.
+func WithTLSClientCertFiles(certFile, keyFile string) Option {
+	return func(opts *Options) {
+		opts.TLS.Enabled = true
+		opts.TLS.CertFile = certFile
+		opts.TLS.KeyFile = keyFile
+	}
+}
+
+// WithTLSClientCertificate enables mutual TLS using an already loaded certificate.
+func WithTLSClientCertificate(certificate tls.Certificate) Option {
+	return func(opts *Options) {
+		opts.TLS.Enabled = true
+		opts.TLS.Certificates = append(opts.TLS.Certificates, certificate)
+	}
+}
+
+// WithTLSServerName enables TLS and overrides the name used to verify the server certificate.
+func WithTLSServerName(serverName string) Option {
+	return func(opts *Options) {
+		opts.TLS.Enabled = true
+		opts.TLS.ServerName = serverName
+	}
+}
+
+// WithTLSInsecureSkipVerify enables TLS without verifying the server certificate.
+// It makes the connection vulnerable to man-in-the-middle attacks and must only be used for development.
+func WithTLSInsecureSkipVerify() Option {
+	return func(opts *Options) {
+		opts.TLS.Enabled = true
+		opts.TLS.InsecureSkipVerify = true
+	}
+}
+
+func (opts TLSOptions) config(serverAddress string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            opts.RootCAs,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if config.ServerName == "" {
+		host, _, err := net.SplitHostPort(serverAddress)
+		if err != nil {
+			return nil, err
+		}
+		config.ServerName = host
+	}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS CA file %s: %w", opts.CAFile, err)
+		}
+		if config.RootCAs == nil {
+			config.RootCAs = x509.NewCertPool()
+		} else {
+			config.RootCAs = config.RootCAs.Clone()
+		}
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in the TLS CA file %s", opts.CAFile)
+		}
+	}
+
+	config.Certificates = append(config.Certificates, opts.Certificates...)
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS client certificate: %w", err)
+		}
+		config.Certificates = append(config.Certificates, certificate)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,138 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	repositoryCertFile = "../../../core/certs/iggy_cert.pem"
+	repositoryKeyFile  = "../../../core/certs/iggy_key.pem"
+)
+
+func TestTLS_InsecureSkipVerifyWithRepositoryCertificate(t *testing.T) {
+	certificate, err := tls.LoadX509KeyPair(repositoryCertFile, repositoryKeyFile)
+	if err != nil {
+		t.Fatalf("failed to load repository certificate: %v", err)
+	}
+	server := startTestTLSServer(t, &tls.Config{Certificates: []tls.Certificate{certificate}}, pingHandler)
+
+	client, err := newTestTcpClient(t, server.address(), WithTLSInsecureSkipVerify())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Errorf("ping over TLS failed: %v", err)
+	}
+}
+
+func TestTLS_RejectsUntrustedServerCertificate(t *testing.T) {
+	certificate, err := tls.LoadX509KeyPair(repositoryCertFile, repositoryKeyFile)
+	if err != nil {
+		t.Fatalf("failed to load repository certificate: %v", err)
+	}
+	server := startTestTLSServer(t, &tls.Config{Certificates: []tls.Certificate{certificate}}, pingHandler)
+
+	// The repository certificate is expired and carries no subject alternative names, so verification must fail.
+	if _, err := newTestTcpClient(t, server.address(), WithTLSCAFile(repositoryCertFile)); err == nil {
+		t.Error("expected the TLS handshake to fail")
+	}
+}
+
+func TestTLS_VerifiesServerWithCAFile(t *testing.T) {
+	ca := createTestCA(t)
+	serverCert := createTestServerCertificate(t, ca)
+	server := startTestTLSServer(t, &tls.Config{Certificates: []tls.Certificate{serverCert.tlsCert}}, pingHandler)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.certificate.Raw})
+	if err := os.WriteFile(caFile, caPem, 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	client, err := newTestTcpClient(t, server.address(), WithTLSCAFile(caFile))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Errorf("ping over TLS failed: %v", err)
+	}
+}
+
+func TestTLS_ServerNameOverride(t *testing.T) {
+	ca := createTestCA(t)
+	serverCert := createTestServerCertificate(t, ca, "iggy.local")
+	server := startTestTLSServer(t, &tls.Config{Certificates: []tls.Certificate{serverCert.tlsCert}}, pingHandler)
+
+	if _, err := newTestTcpClient(t, server.address(), WithTLSRootCAs(ca.pool()), WithTLSServerName("other.local")); err == nil {
+		t.Error("expected the TLS handshake to fail for a mismatching server name")
+	}
+
+	client, err := newTestTcpClient(t, server.address(), WithTLSRootCAs(ca.pool()), WithTLSServerName("iggy.local"))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Errorf("ping over TLS failed: %v", err)
+	}
+}
+
+func TestTLS_MutualTLS(t *testing.T) {
+	ca := createTestCA(t)
+	serverCert := createTestServerCertificate(t, ca)
+	clientCert := createTestClientCertificate(t, ca)
+	server := startTestTLSServer(t, &tls.Config{
+		Certificates: []tls.Certificate{serverCert.tlsCert},
+		ClientCAs:    ca.pool(),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, pingHandler)
+
+	client, err := newTestTcpClient(t, server.address(), WithTLSRootCAs(ca.pool()), WithTLSClientCertificate(clientCert.tlsCert))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Errorf("ping over mutual TLS failed: %v", err)
+	}
+
+	// With TLS 1.3 the server rejects a missing client certificate after the client finished its handshake,
+	// so the failure may only surface on the first request.
+	client, err = newTestTcpClient(t, server.address(), WithTLSRootCAs(ca.pool()))
+	if err == nil {
+		err = client.Ping()
+	}
+	if err == nil {
+		t.Error("expected the server to reject a client without certificate")
+	}
+}
+
+func TestTLS_InvalidCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	if _, err := newTestTcpClient(t, "127.0.0.1:8090", WithTLSCAFile(caFile)); err == nil {
+		t.Error("expected an error for a CA file without certificates")
+	}
+}
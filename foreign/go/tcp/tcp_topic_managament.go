@@ -18,7 +18,7 @@
 package tcp
 
 import (
-	"time"
+	"context"
 
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
@@ -26,8 +26,12 @@ import (
 )
 
 func (tms *IggyTcpClient) GetTopics(streamId Identifier) ([]Topic, error) {
+	return tms.GetTopicsCtx(tms.ctx, streamId)
+}
+
+func (tms *IggyTcpClient) GetTopicsCtx(ctx context.Context, streamId Identifier) ([]Topic, error) {
 	message := binaryserialization.SerializeIdentifier(streamId)
-	buffer, err := tms.sendAndFetchResponse(message, GetTopicsCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetTopicsCode)
 	if err != nil {
 		return nil, err
 	}
@@ -36,8 +40,12 @@ func (tms *IggyTcpClient) GetTopics(streamId Identifier) ([]Topic, error) {
 }
 
 func (tms *IggyTcpClient) GetTopic(streamId Identifier, topicId Identifier) (*TopicDetails, error) {
+	return tms.GetTopicCtx(tms.ctx, streamId, topicId)
+}
+
+func (tms *IggyTcpClient) GetTopicCtx(ctx context.Context, streamId Identifier, topicId Identifier) (*TopicDetails, error) {
 	message := binaryserialization.SerializeIdentifiers(streamId, topicId)
-	buffer, err := tms.sendAndFetchResponse(message, GetTopicCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetTopicCode)
 	if err != nil {
 		return nil, err
 	}
@@ -56,27 +64,32 @@ func (tms *IggyTcpClient) GetTopic(streamId Identifier, topicId Identifier) (*To
 func (tms *IggyTcpClient) CreateTopic(
 	streamId Identifier,
 	name string,
-	partitionsCount int,
-	compressionAlgorithm uint8,
-	messageExpiry time.Duration,
-	maxTopicSize uint64,
-	replicationFactor *uint8,
+	partitionsCount uint32,
+	config TopicConfig,
 	topicId *int,
 ) (*TopicDetails, error) {
-	if MaxStringLength < len(name) {
-		return nil, ierror.TextTooLong("topic_name")
+	return tms.CreateTopicCtx(tms.ctx, streamId, name, partitionsCount, config, topicId)
+}
+
+func (tms *IggyTcpClient) CreateTopicCtx(
+	ctx context.Context,
+	streamId Identifier,
+	name string,
+	partitionsCount uint32,
+	config TopicConfig,
+	topicId *int,
+) (*TopicDetails, error) {
+	if err := ValidateCreateTopic(name, partitionsCount, config); err != nil {
+		return nil, err
 	}
 	serializedRequest := binaryserialization.TcpCreateTopicRequest{
-		StreamId:             streamId,
-		Name:                 name,
-		PartitionsCount:      partitionsCount,
-		CompressionAlgorithm: compressionAlgorithm,
-		MessageExpiry:        messageExpiry,
-		MaxTopicSize:         maxTopicSize,
-		ReplicationFactor:    replicationFactor,
-		TopicId:              topicId,
+		TopicConfig:     config,
+		StreamId:        streamId,
+		Name:            name,
+		PartitionsCount: partitionsCount,
+		TopicId:         topicId,
 	}
-	buffer, err := tms.sendAndFetchResponse(serializedRequest.Serialize(), CreateTopicCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, serializedRequest.Serialize(), CreateTopicCode)
 	if err != nil {
 		return nil, err
 	}
@@ -88,28 +101,46 @@ func (tms *IggyTcpClient) UpdateTopic(
 	streamId Identifier,
 	topicId Identifier,
 	name string,
-	compressionAlgorithm uint8,
-	messageExpiry time.Duration,
-	maxTopicSize uint64,
-	replicationFactor *uint8,
+	config TopicConfig,
 ) error {
-	if MaxStringLength < len(name) {
-		return ierror.TextTooLong("topic_name")
+	return tms.UpdateTopicCtx(tms.ctx, streamId, topicId, name, config)
+}
+
+func (tms *IggyTcpClient) UpdateTopicCtx(
+	ctx context.Context,
+	streamId Identifier,
+	topicId Identifier,
+	name string,
+	config TopicConfig,
+) error {
+	if err := ValidateUpdateTopic(name, config); err != nil {
+		return err
 	}
 	serializedRequest := binaryserialization.TcpUpdateTopicRequest{
-		StreamId:             streamId,
-		TopicId:              topicId,
-		CompressionAlgorithm: compressionAlgorithm,
-		MessageExpiry:        messageExpiry,
-		MaxTopicSize:         maxTopicSize,
-		ReplicationFactor:    replicationFactor,
-		Name:                 name}
-	_, err := tms.sendAndFetchResponse(serializedRequest.Serialize(), UpdateTopicCode)
+		TopicConfig: config,
+		StreamId:    streamId,
+		TopicId:     topicId,
+		Name:        name}
+	_, err := tms.sendAndFetchResponse(ctx, serializedRequest.Serialize(), UpdateTopicCode)
 	return err
 }
 
 func (tms *IggyTcpClient) DeleteTopic(streamId, topicId Identifier) error {
+	return tms.DeleteTopicCtx(tms.ctx, streamId, topicId)
+}
+
+func (tms *IggyTcpClient) DeleteTopicCtx(ctx context.Context, streamId, topicId Identifier) error {
+	message := binaryserialization.SerializeIdentifiers(streamId, topicId)
+	_, err := tms.sendAndFetchResponse(ctx, message, DeleteTopicCode)
+	return err
+}
+
+func (tms *IggyTcpClient) PurgeTopic(streamId, topicId Identifier) error {
+	return tms.PurgeTopicCtx(tms.ctx, streamId, topicId)
+}
+
+func (tms *IggyTcpClient) PurgeTopicCtx(ctx context.Context, streamId, topicId Identifier) error {
 	message := binaryserialization.SerializeIdentifiers(streamId, topicId)
-	_, err := tms.sendAndFetchResponse(message, DeleteTopicCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, PurgeTopicCode)
 	return err
 }
@@ -18,14 +18,20 @@
 package tcp
 
 import (
+	"context"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
 	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
 func (tms *IggyTcpClient) GetUser(identifier Identifier) (*UserInfoDetails, error) {
+	return tms.GetUserCtx(tms.ctx, identifier)
+}
+
+func (tms *IggyTcpClient) GetUserCtx(ctx context.Context, identifier Identifier) (*UserInfoDetails, error) {
 	message := binaryserialization.SerializeIdentifier(identifier)
-	buffer, err := tms.sendAndFetchResponse(message, GetUserCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetUserCode)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +43,11 @@ func (tms *IggyTcpClient) GetUser(identifier Identifier) (*UserInfoDetails, erro
 }
 
 func (tms *IggyTcpClient) GetUsers() ([]UserInfo, error) {
-	buffer, err := tms.sendAndFetchResponse([]byte{}, GetUsersCode)
+	return tms.GetUsersCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) GetUsersCtx(ctx context.Context) ([]UserInfo, error) {
+	buffer, err := tms.sendAndFetchResponse(ctx, []byte{}, GetUsersCode)
 	if err != nil {
 		return nil, err
 	}
@@ -46,13 +56,17 @@ func (tms *IggyTcpClient) GetUsers() ([]UserInfo, error) {
 }
 
 func (tms *IggyTcpClient) CreateUser(username string, password string, status UserStatus, permissions *Permissions) (*UserInfoDetails, error) {
+	return tms.CreateUserCtx(tms.ctx, username, password, status, permissions)
+}
+
+func (tms *IggyTcpClient) CreateUserCtx(ctx context.Context, username string, password string, status UserStatus, permissions *Permissions) (*UserInfoDetails, error) {
 	message := binaryserialization.SerializeCreateUserRequest(CreateUserRequest{
 		Username:    username,
 		Password:    password,
 		Status:      status,
 		Permissions: permissions,
 	})
-	buffer, err := tms.sendAndFetchResponse(message, CreateUserCode)
+	buffer, err := tms.sendAndFetchResponse(ctx, message, CreateUserCode)
 	if err != nil {
 		return nil, err
 	}
@@ -64,36 +78,52 @@ func (tms *IggyTcpClient) CreateUser(username string, password string, status Us
 }
 
 func (tms *IggyTcpClient) UpdateUser(userID Identifier, username *string, status *UserStatus) error {
+	return tms.UpdateUserCtx(tms.ctx, userID, username, status)
+}
+
+func (tms *IggyTcpClient) UpdateUserCtx(ctx context.Context, userID Identifier, username *string, status *UserStatus) error {
 	message := binaryserialization.SerializeUpdateUser(UpdateUserRequest{
 		UserID:   userID,
 		Username: username,
 		Status:   status,
 	})
-	_, err := tms.sendAndFetchResponse(message, UpdateUserCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, UpdateUserCode)
 	return err
 }
 
 func (tms *IggyTcpClient) DeleteUser(identifier Identifier) error {
+	return tms.DeleteUserCtx(tms.ctx, identifier)
+}
+
+func (tms *IggyTcpClient) DeleteUserCtx(ctx context.Context, identifier Identifier) error {
 	message := binaryserialization.SerializeIdentifier(identifier)
-	_, err := tms.sendAndFetchResponse(message, DeleteUserCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, DeleteUserCode)
 	return err
 }
 
 func (tms *IggyTcpClient) UpdatePermissions(userID Identifier, permissions *Permissions) error {
+	return tms.UpdatePermissionsCtx(tms.ctx, userID, permissions)
+}
+
+func (tms *IggyTcpClient) UpdatePermissionsCtx(ctx context.Context, userID Identifier, permissions *Permissions) error {
 	message := binaryserialization.SerializeUpdateUserPermissionsRequest(UpdatePermissionsRequest{
 		UserID:      userID,
 		Permissions: permissions,
 	})
-	_, err := tms.sendAndFetchResponse(message, UpdatePermissionsCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, UpdatePermissionsCode)
 	return err
 }
 
 func (tms *IggyTcpClient) ChangePassword(userID Identifier, currentPassword string, newPassword string) error {
+	return tms.ChangePasswordCtx(tms.ctx, userID, currentPassword, newPassword)
+}
+
+func (tms *IggyTcpClient) ChangePasswordCtx(ctx context.Context, userID Identifier, currentPassword string, newPassword string) error {
 	message := binaryserialization.SerializeChangePasswordRequest(ChangePasswordRequest{
 		UserID:          userID,
 		CurrentPassword: currentPassword,
 		NewPassword:     newPassword,
 	})
-	_, err := tms.sendAndFetchResponse(message, ChangePasswordCode)
+	_, err := tms.sendAndFetchResponse(ctx, message, ChangePasswordCode)
 	return err
 }
@@ -18,12 +18,20 @@
 package tcp
 
 import (
+	"context"
+	"math"
+
 	binaryserialization "github.com/apache/iggy/foreign/go/binary_serialization"
 	. "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
 )
 
 func (tms *IggyTcpClient) GetStats() (*Stats, error) {
-	buffer, err := tms.sendAndFetchResponse([]byte{}, GetStatsCode)
+	return tms.GetStatsCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) GetStatsCtx(ctx context.Context) (*Stats, error) {
+	buffer, err := tms.sendAndFetchResponse(ctx, []byte{}, GetStatsCode)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +42,55 @@ func (tms *IggyTcpClient) GetStats() (*Stats, error) {
 	return &stats.Stats, err
 }
 
+func (tms *IggyTcpClient) GetSnapshot(compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error) {
+	return tms.GetSnapshotCtx(tms.ctx, compression, snapshotTypes)
+}
+
+func (tms *IggyTcpClient) GetSnapshotCtx(ctx context.Context, compression SnapshotCompression, snapshotTypes []SnapshotType) (Snapshot, error) {
+	if len(snapshotTypes) > math.MaxUint8 {
+		return nil, ierror.InvalidCommand
+	}
+	message := binaryserialization.GetSnapshot(GetSnapshotRequest{
+		Compression:   compression,
+		SnapshotTypes: snapshotTypes,
+	})
+	buffer, err := tms.sendAndFetchResponse(ctx, message, GetSnapshotFileCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return Snapshot(buffer), nil
+}
+
+// ServerVersion returns the version of the server detected when logging in, it is unknown before.
+func (tms *IggyTcpClient) ServerVersion() ServerVersion {
+	if version := tms.serverVersion.Load(); version != nil {
+		return *version
+	}
+	return ServerVersion{}
+}
+
+// detectServerVersion reads the server version from its stats, which require an authenticated session.
+// A failure leaves the version unknown.
+func (tms *IggyTcpClient) detectServerVersion(ctx context.Context) {
+	if buffer, err := tms.sendAndFetchResponse(ctx, []byte{}, GetStatsCode); err == nil {
+		tms.setServerVersion(buffer)
+	}
+}
+
+func (tms *IggyTcpClient) setServerVersion(buffer []byte) {
+	stats := &binaryserialization.TcpStats{}
+	if err := stats.Deserialize(buffer); err == nil {
+		version := ServerVersionOf(&stats.Stats)
+		tms.serverVersion.Store(&version)
+	}
+}
+
 func (tms *IggyTcpClient) Ping() error {
-	_, err := tms.sendAndFetchResponse([]byte{}, PingCode)
+	return tms.PingCtx(tms.ctx)
+}
+
+func (tms *IggyTcpClient) PingCtx(ctx context.Context) error {
+	_, err := tms.sendAndFetchResponse(ctx, []byte{}, PingCode)
 	return err
 }
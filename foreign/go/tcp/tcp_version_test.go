@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+
+	iggcon "github.com/apache/iggy/foreign/go/contracts"
+	ierror "github.com/apache/iggy/foreign/go/errors"
+)
+
+// versionedServer starts a server reporting the given version in its stats, and rejecting the purges as invalid
+// commands.
+func versionedServer(t *testing.T, recorder *commandRecorder, version string, semver iggcon.SemanticVersion) *testServer {
+	return startTestServer(t, func(command iggcon.CommandCode, payload []byte) (uint32, []byte) {
+		status, response := recorder.handle(command, payload)
+		switch command {
+		case iggcon.GetStatsCode:
+			stats := make([]byte, 108)
+			for _, value := range []string{"host", "os", "1.0", "6.4", version} {
+				stats = binary.LittleEndian.AppendUint32(stats, uint32(len(value)))
+				stats = append(stats, value...)
+			}
+			return 0, binary.LittleEndian.AppendUint32(stats, uint32(semver))
+		case iggcon.PurgeStreamCode, iggcon.PurgeTopicCode:
+			return uint32(ierror.InvalidCommand.Code), nil
+		}
+		return status, response
+	})
+}
+
+func TestServerVersion_DetectedOnLogin(t *testing.T) {
+	server := versionedServer(t, &commandRecorder{}, "0.4.300", iggcon.NewSemanticVersion(0, 4, 300))
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if client.ServerVersion().Known() {
+		t.Fatalf("expected the version to be unknown before logging in, got %v", client.ServerVersion())
+	}
+
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	expected := iggcon.ServerVersion{Version: "0.4.300", Semver: iggcon.NewSemanticVersion(0, 4, 300)}
+	if version := client.ServerVersion(); version != expected {
+		t.Fatalf("expected the version %+v, got %+v", expected, version)
+	}
+}
+
+func TestServerVersion_ReportsUnsupportedCommands(t *testing.T) {
+	recorder := &commandRecorder{}
+	server := versionedServer(t, recorder, "0.1.0", iggcon.NewSemanticVersion(0, 1, 0))
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if _, err := client.LoginUser("iggy", "iggy"); err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	recorder.reset()
+
+	err = client.PurgeTopic(iggcon.NewIdentifier(1), iggcon.NewIdentifier(2))
+	var unsupported *iggcon.UnsupportedCommandError
+	if !errors.As(err, &unsupported) || unsupported.Command != iggcon.PurgeTopicCode || unsupported.Server != client.ServerVersion() {
+		t.Fatalf("expected the command to be unsupported, got %v", err)
+	}
+	var iggyErr *ierror.IggyError
+	if !errors.As(err, &iggyErr) || iggyErr.Code != ierror.InvalidCommand.Code {
+		t.Errorf("expected the error of the server to be kept, got %v", err)
+	}
+	if expected := "command topic.purge is unsupported by server v0.1.0: 3: 'invalid_command'"; err.Error() != expected {
+		t.Errorf("expected the error %q, got %q", expected, err.Error())
+	}
+	if received := recorder.received(); !slices.Equal(received, []iggcon.CommandCode{iggcon.PurgeTopicCode}) {
+		t.Errorf("expected the command to be sent, got %v", received)
+	}
+}
+
+func TestServerVersion_KeepsInvalidCommandOfOtherCommands(t *testing.T) {
+	server := startTestServer(t, func(iggcon.CommandCode, []byte) (uint32, []byte) {
+		return uint32(ierror.InvalidCommand.Code), nil
+	})
+	client, err := newTestTcpClient(t, server.address())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	err = client.Ping()
+	var unsupported *iggcon.UnsupportedCommandError
+	var iggyErr *ierror.IggyError
+	if errors.As(err, &unsupported) || !errors.As(err, &iggyErr) || iggyErr.Code != ierror.InvalidCommand.Code {
+		t.Fatalf("expected the invalid command error of the server, got %v", err)
+	}
+}